@@ -0,0 +1,124 @@
+// Package hd implements BIP-39 mnemonic seeds and BIP-32 hierarchical
+// deterministic derivation of secp256k1 keys.
+package hd
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/browser/crypto"
+)
+
+// HardenedKeyStart is the first hardened child index.
+const HardenedKeyStart = 1 << 31
+
+var (
+	ErrInvalidPath = errors.New("invalid key derivation path")
+	ErrInvalidKey  = errors.New("invalid derived key")
+)
+
+// ExtendedKey is a BIP-32 extended private key.
+type ExtendedKey struct {
+	Key       []byte // 32-byte private key
+	ChainCode []byte // 32-byte chain code
+}
+
+// NewMasterKey returns the master key for seed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	if err := checkKey(sum[:32]); err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{Key: sum[:32], ChainCode: sum[32:]}, nil
+}
+
+// Child returns the child key at index, hardened if index is at least
+// HardenedKeyStart.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, k.ChainCode)
+	if index >= HardenedKeyStart {
+		mac.Write([]byte{0})
+		mac.Write(k.Key)
+	} else {
+		priv, err := k.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		mac.Write(crypto.CompressPubkey(&priv.PublicKey))
+	}
+	var i [4]byte
+	binary.BigEndian.PutUint32(i[:], index)
+	mac.Write(i[:])
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, fmt.Errorf("%w: child %d", ErrInvalidKey, index)
+	}
+	child := il.Add(il, new(big.Int).SetBytes(k.Key))
+	child.Mod(child, n)
+	key := make([]byte, 32)
+	child.FillBytes(key)
+	if err := checkKey(key); err != nil {
+		return nil, fmt.Errorf("%w: child %d", err, index)
+	}
+	return &ExtendedKey{Key: key, ChainCode: sum[32:]}, nil
+}
+
+// Derive walks path from k.
+func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey returns k as an ECDSA private key.
+func (k *ExtendedKey) PrivateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(k.Key)
+}
+
+// checkKey rejects keys that are zero or not below the curve order.
+func checkKey(key []byte) error {
+	d := new(big.Int).SetBytes(key)
+	if d.Sign() == 0 || d.Cmp(crypto.S256().Params().N) >= 0 {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// ParsePath parses a derivation path of the form m/44'/60'/0'/0/0, where a
+// trailing ' or h marks a hardened index.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q does not start at m", ErrInvalidPath, path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var offset uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H") {
+			part, offset = part[:len(part)-1], HardenedKeyStart
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidPath, path, err)
+		}
+		indexes = append(indexes, uint32(index)+offset)
+	}
+	return indexes, nil
+}
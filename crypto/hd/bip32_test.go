@@ -0,0 +1,105 @@
+package hd
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+// TestDeriveBIP32Vector1 checks derivation against test vector 1 of BIP-32.
+// Private keys are given where the vector's xprv is used, public keys in
+// compressed form.
+func TestDeriveBIP32Vector1(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path      string
+		chainCode string
+		pubKey    string
+		privKey   string
+	}{
+		{
+			"m",
+			"873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+			"0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+			"",
+		},
+		{
+			"m/0H",
+			"47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+			"035a784662a4a20a65bf6aab9ae98a6c068a81c52e4b032c0fb5400c706cfccc56",
+			"edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		},
+		{
+			"m/0H/1",
+			"2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+			"03501e454bf00751f24b1b489aa925215d66af2234e3891c3b21a52bedb3cd711c",
+			"3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		},
+		{
+			"m/0H/1/2H",
+			"04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f",
+			"0357bfe1e341d01c69fe5654309956cbea516822fba8a601743a012a7896ee8dc2",
+			"cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		},
+		{
+			"m/0H/1/2H/2",
+			"cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd",
+			"02e8445082a72f29b75ca48748a914df60622a609cacfce8ed0e35804560741d29",
+			"0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+		},
+		{
+			"m/0H/1/2H/2/1000000000",
+			"c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e",
+			"022a471424da5e657499d1ff51cb43c47481a03b1e77f951fe64cec9f5a48f7011",
+			"471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+		},
+	}
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatalf("%s: %v", test.path, err)
+		}
+		if got := hex.EncodeToString(key.ChainCode); got != test.chainCode {
+			t.Errorf("%s: got chain code %s, want %s", test.path, got, test.chainCode)
+		}
+		if test.privKey != "" {
+			if got := hex.EncodeToString(key.Key); got != test.privKey {
+				t.Errorf("%s: got private key %s, want %s", test.path, got, test.privKey)
+			}
+		}
+		priv, err := key.PrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := hex.EncodeToString(crypto.CompressPubkey(&priv.PublicKey)); got != test.pubKey {
+			t.Errorf("%s: got public key %s, want %s", test.path, got, test.pubKey)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := ParsePath("m/44'/60'/0h/0/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{HardenedKeyStart + 44, HardenedKeyStart + 60, HardenedKeyStart, 0, 7}
+	if !reflect.DeepEqual(path, want) {
+		t.Errorf("got %v, want %v", path, want)
+	}
+	for _, path := range []string{"44'/60'", "m/x", "m/2147483648", "m//0"} {
+		if _, err := ParsePath(path); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("%q: got %v, want %v", path, err, ErrInvalidPath)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package hd
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// WordlistSize is the number of words in a BIP-39 wordlist.
+const WordlistSize = 2048
+
+var (
+	ErrInvalidWordlist  = errors.New("invalid BIP-39 wordlist")
+	ErrMnemonicLength   = errors.New("invalid mnemonic length")
+	ErrUnknownWord      = errors.New("word not in BIP-39 wordlist")
+	ErrMnemonicChecksum = errors.New("invalid mnemonic checksum")
+)
+
+// Wordlist is a BIP-39 wordlist, such as the English list published with
+// BIP-39. Word indexes are fixed by the standard, so a mnemonic only checks
+// out against the list it was generated from.
+type Wordlist struct {
+	words []string
+	index map[string]int
+}
+
+// NewWordlist returns a Wordlist of words, which must hold WordlistSize
+// distinct, non-empty words in BIP-39 order.
+func NewWordlist(words []string) (*Wordlist, error) {
+	if len(words) != WordlistSize {
+		return nil, fmt.Errorf("%w: got %d words, want %d", ErrInvalidWordlist, len(words), WordlistSize)
+	}
+	w := &Wordlist{words: make([]string, len(words)), index: make(map[string]int, len(words))}
+	for i, word := range words {
+		word = norm.NFKD.String(strings.TrimSpace(word))
+		if word == "" {
+			return nil, fmt.Errorf("%w: empty word at index %d", ErrInvalidWordlist, i)
+		}
+		if _, ok := w.index[word]; ok {
+			return nil, fmt.Errorf("%w: duplicate word %q", ErrInvalidWordlist, word)
+		}
+		w.words[i] = word
+		w.index[word] = i
+	}
+	return w, nil
+}
+
+// LoadWordlist reads a wordlist with one word per line, the format of the
+// lists published with BIP-39.
+func LoadWordlist(r io.Reader) (*Wordlist, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			words = append(words, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return NewWordlist(words)
+}
+
+// NewMnemonic encodes entropy of 16, 20, 24, 28 or 32 bytes as a mnemonic.
+func (w *Wordlist) NewMnemonic(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", fmt.Errorf("%w: %d bytes of entropy", ErrMnemonicLength, len(entropy))
+	}
+	checksumBits := uint(len(entropy) / 4)
+	sum := sha256.Sum256(entropy)
+	bits := new(big.Int).SetBytes(entropy)
+	bits.Lsh(bits, checksumBits)
+	bits.Or(bits, big.NewInt(int64(sum[0]>>(8-checksumBits))))
+
+	words := make([]string, (len(entropy)*8+int(checksumBits))/11)
+	mask := big.NewInt(WordlistSize - 1)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = w.words[new(big.Int).And(bits, mask).Int64()]
+		bits.Rsh(bits, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// ValidateMnemonic checks that mnemonic is 12, 15, 18, 21 or 24 words of w
+// with a valid checksum, so that a mistyped phrase is caught before any key
+// is derived from it.
+func (w *Wordlist) ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return fmt.Errorf("%w: %d words", ErrMnemonicLength, len(words))
+	}
+	bits := new(big.Int)
+	for _, word := range words {
+		i, ok := w.index[word]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownWord, word)
+		}
+		bits.Lsh(bits, 11)
+		bits.Or(bits, big.NewInt(int64(i)))
+	}
+	checksumBits := uint(len(words) / 3)
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1)).Int64()
+	bits.Rsh(bits, checksumBits)
+
+	entropy := make([]byte, len(words)*4/3)
+	bits.FillBytes(entropy)
+	sum := sha256.Sum256(entropy)
+	if int64(sum[0]>>(8-checksumBits)) != checksum {
+		return ErrMnemonicChecksum
+	}
+	return nil
+}
+
+// NewSeed returns the BIP-39 seed for mnemonic and an optional passphrase. It
+// does not validate the mnemonic; see Wordlist.ValidateMnemonic.
+func NewSeed(mnemonic, passphrase string) []byte {
+	password := []byte(norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " ")))
+	salt := []byte("mnemonic" + norm.NFKD.String(passphrase))
+	return pbkdf2SHA512(password, salt, 2048, 64)
+}
+
+// pbkdf2SHA512 implements PBKDF2 (RFC 8018) with HMAC-SHA512.
+func pbkdf2SHA512(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha512.New, password)
+	var dk []byte
+	for block := uint32(1); len(dk) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], block)
+		prf.Write(b[:])
+		u := prf.Sum(nil)
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		dk = append(dk, t...)
+	}
+	return dk[:keyLen]
+}
@@ -0,0 +1,115 @@
+package hd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testWordlist returns a wordlist of placeholder words w0000 to w2047, with
+// the words of known placed at their BIP-39 indexes.
+func testWordlist(t *testing.T, known map[int]string) *Wordlist {
+	words := make([]string, WordlistSize)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%04d", i)
+	}
+	for i, word := range known {
+		words[i] = word
+	}
+	w, err := NewWordlist(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestMnemonicChecksum(t *testing.T) {
+	w := testWordlist(t, nil)
+	// Word indexes of the BIP-39 test vectors for these entropies, e.g.
+	// 0x00 * 16 is "abandon" (0) * 11 followed by "about" (3).
+	tests := []struct {
+		entropy string
+		indexes []int
+	}{
+		{strings.Repeat("00", 16), []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3}},
+		{strings.Repeat("7f", 16), []int{1019, 2015, 1790, 2039, 1983, 1533, 2031, 1919, 1019, 2015, 1790, 2040}},
+		{strings.Repeat("80", 16), []int{1028, 32, 257, 8, 64, 514, 16, 128, 1028, 32, 257, 4}},
+		{strings.Repeat("ff", 16), []int{2047, 2047, 2047, 2047, 2047, 2047, 2047, 2047, 2047, 2047, 2047, 2037}},
+		{strings.Repeat("00", 32), append(make([]int, 23), 102)},
+	}
+	for _, test := range tests {
+		var words []string
+		for _, i := range test.indexes {
+			words = append(words, fmt.Sprintf("w%04d", i))
+		}
+		want := strings.Join(words, " ")
+
+		entropy, _ := hex.DecodeString(test.entropy)
+		mnemonic, err := w.NewMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != want {
+			t.Errorf("%s: got %q, want %q", test.entropy, mnemonic, want)
+		}
+		if err := w.ValidateMnemonic(want); err != nil {
+			t.Errorf("%s: %v", test.entropy, err)
+		}
+	}
+
+	bad := []struct {
+		mnemonic string
+		err      error
+	}{
+		{strings.Repeat("w0000 ", 12), ErrMnemonicChecksum},
+		{strings.Repeat("w0000 ", 11) + "w0004", ErrMnemonicChecksum},
+		{strings.Repeat("w0000 ", 11) + "about", ErrUnknownWord},
+		{strings.Repeat("w0000 ", 11), ErrMnemonicLength},
+		{"", ErrMnemonicLength},
+	}
+	for _, test := range bad {
+		if err := w.ValidateMnemonic(test.mnemonic); !errors.Is(err, test.err) {
+			t.Errorf("%q: got %v, want %v", test.mnemonic, err, test.err)
+		}
+	}
+	if _, err := w.NewMnemonic(make([]byte, 15)); !errors.Is(err, ErrMnemonicLength) {
+		t.Errorf("15 bytes of entropy: got %v, want %v", err, ErrMnemonicLength)
+	}
+}
+
+func TestNewSeed(t *testing.T) {
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+	tests := []struct {
+		passphrase string
+		seed       string
+	}{
+		{"", "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"},
+		{"TREZOR", "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"},
+	}
+	for _, test := range tests {
+		if got := hex.EncodeToString(NewSeed(mnemonic, test.passphrase)); got != test.seed {
+			t.Errorf("passphrase %q: got seed %s, want %s", test.passphrase, got, test.seed)
+		}
+	}
+}
+
+func TestLoadWordlist(t *testing.T) {
+	var list bytes.Buffer
+	for i := 0; i < WordlistSize; i++ {
+		fmt.Fprintf(&list, "w%04d\n", i)
+	}
+	if _, err := LoadWordlist(bytes.NewReader(list.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	short := list.Bytes()[:list.Len()-len("w2047\n")]
+	if _, err := LoadWordlist(bytes.NewReader(short)); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("2047 words: got %v, want %v", err, ErrInvalidWordlist)
+	}
+	duplicate := append(append([]byte{}, short...), "w0000\n"...)
+	if _, err := LoadWordlist(bytes.NewReader(duplicate)); !errors.Is(err, ErrInvalidWordlist) {
+		t.Errorf("duplicate word: got %v, want %v", err, ErrInvalidWordlist)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"golang.org/x/crypto/sha3"
 
+	"github.com/browser/crypto"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
@@ -154,3 +155,35 @@ func BytesToPubKey(b []byte) PubKey {
 	a.SetBytes(b)
 	return a
 }
+
+// AddressDeriver derives the address controlled by a public key.
+type AddressDeriver interface {
+	Derive(PubKey) (Address, error)
+}
+
+// AddressDeriverFunc adapts a function to the AddressDeriver interface.
+type AddressDeriverFunc func(PubKey) (Address, error)
+
+// Derive calls f(p).
+func (f AddressDeriverFunc) Derive(p PubKey) (Address, error) { return f(p) }
+
+// Keccak256Deriver derives the address as the last 20 bytes of the Keccak-256
+// hash of the uncompressed public key, as the fractal node does.
+type Keccak256Deriver struct{}
+
+// Derive implements AddressDeriver.
+func (Keccak256Deriver) Derive(p PubKey) (Address, error) {
+	if _, err := crypto.UnmarshalPubkey(p[:]); err != nil {
+		return Address{}, err
+	}
+	return BytesToAddress(crypto.Keccak256(p[1:])[12:]), nil
+}
+
+// DefaultAddressDeriver is the deriver used by PubKeyToAddress.
+var DefaultAddressDeriver AddressDeriver = Keccak256Deriver{}
+
+// PubKeyToAddress returns the address derived from the public key p by
+// DefaultAddressDeriver.
+func PubKeyToAddress(p PubKey) (Address, error) {
+	return DefaultAddressDeriver.Derive(p)
+}
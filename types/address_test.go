@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+func TestPubKeyToAddress(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := BytesToPubKey(crypto.FromECDSAPub(&key.PublicKey))
+	address, err := PubKeyToAddress(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); address.Compare(BytesToAddress(want.Bytes())) != 0 {
+		t.Errorf("got %v, want %v", address, want)
+	}
+	if _, err := PubKeyToAddress(HexToPubKey(testPubKeyHex)); err == nil {
+		t.Errorf("derived an address from an invalid public key")
+	}
+}
+
+func TestCustomAddressDeriver(t *testing.T) {
+	defer func(d AddressDeriver) { DefaultAddressDeriver = d }(DefaultAddressDeriver)
+	DefaultAddressDeriver = AddressDeriverFunc(func(p PubKey) (Address, error) {
+		return BytesToAddress(p[PubKeyLength-AddressLength:]), nil
+	})
+
+	pubKey := HexToPubKey(testPubKeyHex)
+	address, err := PubKeyToAddress(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := BytesToAddress(pubKey[PubKeyLength-AddressLength:]); address != want {
+		t.Errorf("got %v, want %v", address, want)
+	}
+}
@@ -1,11 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/browser/rlp"
-	"github.com/fractalplatform/fractal/common"
 	"io"
 	"strings"
 )
@@ -47,6 +48,92 @@ type (
 	}
 )
 
+var (
+	ErrInvalidAuthorType = errors.New("invalid author type")
+	ErrInvalidOwner      = errors.New("invalid author owner")
+	ErrZeroWeightAuthor  = errors.New("author has zero weight")
+)
+
+// ParseAuthorType returns the AuthorType named by s, matched case-insensitively
+// against the names in DefaultAuthorTypeRegistry.
+func ParseAuthorType(s string) (AuthorType, error) {
+	if at, ok := DefaultAuthorTypeRegistry.TypeByName(s); ok {
+		return at, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrInvalidAuthorType, s)
+}
+
+// GenerateOwnerE is like GenerateOwner but reports malformed owners instead of
+// silently producing a zero value.
+func GenerateOwnerE(author string, at AuthorType) (Owner, error) {
+	switch at {
+	case AccountNameType:
+		if len(author) == 0 {
+			return nil, fmt.Errorf("%w: empty name", ErrInvalidOwner)
+		}
+		name, err := parseName(author)
+		if err != nil {
+			return nil, err
+		}
+		return name, nil
+	case PubKeyType:
+		normalized, err := NormalizePubKeyHex(author)
+		if err != nil {
+			return nil, err
+		}
+		return HexToPubKey(normalized), nil
+	case AddressType:
+		b, err := decodeOwnerHex(author, AddressLength)
+		if err != nil {
+			return nil, err
+		}
+		return BytesToAddress(b), nil
+	}
+	if _, ok := DefaultAuthorTypeRegistry.Lookup(at); !ok {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidAuthorType, at)
+	}
+	b, err := decodeOwnerHex(author, -1)
+	if err != nil {
+		return nil, err
+	}
+	return newOwner(at, b)
+}
+
+// NormalizePubKeyHex returns the public key s as bare lowercase hex. s may be
+// surrounded by whitespace and carry one 0x or 0X prefix, but must otherwise
+// be an even number of hex digits encoding exactly PubKeyLength bytes.
+func NormalizePubKeyHex(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if hasHexPrefix(s) {
+		s = s[2:]
+	}
+	if !isHex(s) {
+		return "", fmt.Errorf("%w: pubkey %q is not even-length hex", ErrInvalidOwner, s)
+	}
+	if len(s) != 2*PubKeyLength {
+		return "", fmt.Errorf("%w: got %d pubkey bytes, want %d", ErrInvalidOwner, len(s)/2, PubKeyLength)
+	}
+	return strings.ToLower(s), nil
+}
+
+// decodeOwnerHex decodes an optionally 0x-prefixed hex owner, checking its
+// length unless length is negative. Like NormalizePubKeyHex it ignores
+// surrounding whitespace.
+func decodeOwnerHex(s string, length int) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if hasHexPrefix(s) {
+		s = s[2:]
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOwner, err)
+	}
+	if length >= 0 && len(b) != length {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidOwner, len(b), length)
+	}
+	return b, nil
+}
+
 func GenerateOwner(author string, at AuthorType) Owner {
 	f := func(in string) []byte {
 		formatStr := in
@@ -75,6 +162,78 @@ func GenerateOwner(author string, at AuthorType) Owner {
 	return copyOwner
 }
 
+// newOwner builds an owner of type at from its raw payload: the name's bytes,
+// or the key or address bytes.
+func newOwner(at AuthorType, b []byte) (Owner, error) {
+	switch at {
+	case AccountNameType:
+		return GenerateOwnerE(string(b), at)
+	case PubKeyType:
+		if len(b) != PubKeyLength {
+			return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidOwner, len(b), PubKeyLength)
+		}
+		return BytesToPubKey(b), nil
+	case AddressType:
+		if len(b) != AddressLength {
+			return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidOwner, len(b), AddressLength)
+		}
+		return BytesToAddress(b), nil
+	}
+	if info, ok := DefaultAuthorTypeRegistry.Lookup(at); ok && info.Decode != nil {
+		return info.Decode(b)
+	}
+	return nil, fmt.Errorf("%w: %d", ErrInvalidAuthorType, at)
+}
+
+// OwnerType returns the AuthorType of owner, or false if owner is not a known
+// author owner.
+func OwnerType(owner Owner) (AuthorType, bool) {
+	switch o := owner.(type) {
+	case Name:
+		return AccountNameType, true
+	case PubKey:
+		return PubKeyType, true
+	case Address:
+		return AddressType, true
+	case TypedOwner:
+		return o.AuthorType(), true
+	}
+	return 0, false
+}
+
+// OwnerKey returns a string identifying owner across author types, e.g.
+// "pubKey:0x04ab...". Hex owners are lowercased so that checksummed and plain
+// spellings of the same owner share a key; names are kept as is, since the
+// node treats names differing in case as distinct accounts.
+func OwnerKey(owner Owner) string {
+	at, _ := OwnerType(owner)
+	if at == AccountNameType {
+		return authorTypeName(at) + ":" + owner.String()
+	}
+	return authorTypeName(at) + ":" + strings.ToLower(owner.String())
+}
+
+func ownerBytes(owner Owner) []byte {
+	switch o := owner.(type) {
+	case Name:
+		return []byte(o)
+	case PubKey:
+		return o.Bytes()
+	case Address:
+		return o.Bytes()
+	case TypedOwner:
+		return o.Bytes()
+	}
+	return []byte(owner.String())
+}
+
+// Equal reports whether a and b have the same owner and weight.
+func (a *Author) Equal(b *Author) bool {
+	at, _ := OwnerType(a.Owner)
+	bt, _ := OwnerType(b.Owner)
+	return at == bt && a.Weight == b.Weight && bytes.Equal(ownerBytes(a.Owner), ownerBytes(b.Owner))
+}
+
 type StorageAuthor struct {
 	Type    AuthorType
 	DataRaw rlp.RawValue
@@ -89,6 +248,51 @@ func (a *Author) GetWeight() uint64 {
 	return a.Weight
 }
 
+// WeightRedacted returns a copy of a with its weight zeroed, for exports that
+// may reveal owners but not their weights. The copy's weight is meaningless,
+// so it must never be encoded for the chain.
+func (a *Author) WeightRedacted() *Author {
+	return &Author{Owner: a.Owner}
+}
+
+// RedactWeights returns WeightRedacted copies of authors.
+func RedactWeights(authors []*Author) []*Author {
+	redacted := make([]*Author, len(authors))
+	for i, a := range authors {
+		redacted[i] = a.WeightRedacted()
+	}
+	return redacted
+}
+
+// AuthorView is a read-only view of an Author. Bytes returns a copy of the
+// owner payload, so holders of a view cannot modify the underlying author.
+type AuthorView interface {
+	Type() AuthorType
+	OwnerString() string
+	Weight() uint64
+	Bytes() []byte
+}
+
+type authorView struct {
+	a *Author
+}
+
+func (v authorView) Type() AuthorType {
+	at, _ := OwnerType(v.a.Owner)
+	return at
+}
+
+func (v authorView) OwnerString() string { return v.a.Owner.String() }
+
+func (v authorView) Weight() uint64 { return v.a.Weight }
+
+func (v authorView) Bytes() []byte { return CopyBytes(ownerBytes(v.a.Owner)) }
+
+// ReadOnly returns a read-only view of a.
+func (a *Author) ReadOnly() AuthorView {
+	return authorView{a: a}
+}
+
 func (a *Author) EncodeRLP(w io.Writer) error {
 	storageAuthor, err := a.encode()
 	if err != nil {
@@ -129,6 +333,19 @@ func (a *Author) encode() (*StorageAuthor, error) {
 			DataRaw: value,
 			Weight:  a.Weight,
 		}, nil
+	case TypedOwner:
+		if _, ok := DefaultAuthorTypeRegistry.Lookup(aTy.AuthorType()); !ok {
+			break
+		}
+		value, err := rlp.EncodeToBytes(aTy.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		return &StorageAuthor{
+			Type:    aTy.AuthorType(),
+			DataRaw: value,
+			Weight:  a.Weight,
+		}, nil
 	}
 	return nil, errors.New("author encode failed")
 }
@@ -142,6 +359,19 @@ func (a *Author) DecodeRLP(s *rlp.Stream) error {
 	return a.decode(storageAuthor)
 }
 
+// DecodeRLPStrict decodes like DecodeRLP but rejects authors with zero weight,
+// which carry no meaning for consensus. Full-validation paths should use it;
+// DecodeRLP stays lenient so that indexing can read whatever is on chain.
+func (a *Author) DecodeRLPStrict(s *rlp.Stream) error {
+	if err := a.DecodeRLP(s); err != nil {
+		return err
+	}
+	if a.Weight == 0 {
+		return fmt.Errorf("%w: %s", ErrZeroWeightAuthor, a.Owner)
+	}
+	return nil
+}
+
 func (a *Author) decode(sa *StorageAuthor) error {
 	switch sa.Type {
 	case AccountNameType:
@@ -169,15 +399,56 @@ func (a *Author) decode(sa *StorageAuthor) error {
 		a.Weight = sa.Weight
 		return nil
 	}
+	if info, ok := DefaultAuthorTypeRegistry.Lookup(sa.Type); ok && info.Decode != nil {
+		var payload []byte
+		if err := rlp.DecodeBytes(sa.DataRaw, &payload); err != nil {
+			return err
+		}
+		owner, err := info.Decode(payload)
+		if err != nil {
+			return err
+		}
+		a.Owner = owner
+		a.Weight = sa.Weight
+		return nil
+	}
 	return errors.New("author decode failed")
 }
 
+// DecodeAuthors decodes an RLP list of authors. Lists of StorageAuthor
+// values, of pointers to them and of Authors all share one encoding and are
+// all accepted. An encoded empty list yields an empty, non-nil slice, while
+// empty input, as read for a missing value, yields nil.
+func DecodeAuthors(b []byte) ([]*Author, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	var sas []*StorageAuthor
+	if err := rlp.DecodeBytes(b, &sas); err != nil {
+		return nil, err
+	}
+	authors := make([]*Author, len(sas))
+	for i, sa := range sas {
+		authors[i] = new(Author)
+		if err := authors[i].decode(sa); err != nil {
+			return nil, fmt.Errorf("author %d: %v", i, err)
+		}
+	}
+	return authors, nil
+}
+
 type AuthorJSON struct {
 	authorType AuthorType
 	OwnerStr   string `json:"owner"`
 	Weight     uint64 `json:"weight"`
 }
 
+// MarshalJSON encodes a in the fractal node's author format,
+// {"owner":"...","weight":N}. Names are emitted as is, public keys as
+// 0x-prefixed lowercase hex and addresses as 0x-prefixed EIP-55 checksummed
+// hex. The owner type is not emitted for these, matching the node; owners of
+// other registered types, which the node does not know, carry a "type" field
+// so that they decode back to the same type.
 func (a *Author) MarshalJSON() ([]byte, error) {
 	switch aTy := a.Owner.(type) {
 	case Name:
@@ -186,25 +457,55 @@ func (a *Author) MarshalJSON() ([]byte, error) {
 		return json.Marshal(&AuthorJSON{authorType: PubKeyType, OwnerStr: aTy.String(), Weight: a.Weight})
 	case Address:
 		return json.Marshal(&AuthorJSON{authorType: AddressType, OwnerStr: aTy.String(), Weight: a.Weight})
+	case TypedOwner:
+		name, ok := DefaultAuthorTypeRegistry.Name(aTy.AuthorType())
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidAuthorType, aTy.AuthorType())
+		}
+		return json.Marshal(&rawAuthorJSON{Owner: aTy.String(), Weight: a.Weight, Type: &name})
 	}
 	return nil, errors.New("Author marshal failed")
 }
 
+// UnmarshalJSON decodes the node's author format. The owner type is not part
+// of that format, so it is inferred with InferOwner; an explicit "type" field
+// is honoured when present.
 func (a *Author) UnmarshalJSON(data []byte) error {
-	aj := &AuthorJSON{}
-	if err := json.Unmarshal(data, aj); err != nil {
+	var raw rawAuthorJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
-	switch aj.authorType {
-	case AccountNameType:
-		a.Owner = Name(aj.OwnerStr)
-		a.Weight = aj.Weight
-	case PubKeyType:
-		a.Owner = HexToPubKey(aj.OwnerStr)
-		a.Weight = aj.Weight
-	case AddressType:
-		a.Owner = common.HexToAddress(aj.OwnerStr)
-		a.Weight = aj.Weight
+	decoded, err := raw.author()
+	if err != nil {
+		return err
+	}
+	*a = *decoded
+	return nil
+}
+
+var ErrEncodingMismatch = errors.New("author JSON and RLP encodings differ")
+
+// VerifyEncodingsMatch checks that jsonBytes and rlpBytes encode the same
+// author. The JSON form does not carry the owner type, so its owner string is
+// parsed as the type found in the RLP form.
+func VerifyEncodingsMatch(jsonBytes, rlpBytes []byte) error {
+	fromRLP := new(Author)
+	if err := rlp.DecodeBytes(rlpBytes, fromRLP); err != nil {
+		return fmt.Errorf("decode rlp: %v", err)
+	}
+	aj := new(AuthorJSON)
+	if err := json.Unmarshal(jsonBytes, aj); err != nil {
+		return fmt.Errorf("decode json: %v", err)
+	}
+	at, _ := OwnerType(fromRLP.Owner)
+	owner, err := GenerateOwnerE(aj.OwnerStr, at)
+	if err != nil {
+		return fmt.Errorf("%w: json owner %q is not a valid %s: %v", ErrEncodingMismatch, aj.OwnerStr, authorTypeName(at), err)
+	}
+	fromJSON := NewAuthor(owner, aj.Weight)
+	if !fromJSON.Equal(fromRLP) {
+		return fmt.Errorf("%w: json has %s with weight %d, rlp has %s with weight %d",
+			ErrEncodingMismatch, fromJSON.Owner, fromJSON.Weight, fromRLP.Owner, fromRLP.Weight)
 	}
 	return nil
 }
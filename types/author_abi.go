@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const abiWordSize = 32
+
+var ErrInvalidABI = errors.New("invalid ABI encoded authors")
+
+// abiWord returns the 32-byte word of data at off.
+func abiWord(data []byte, off uint64) ([]byte, error) {
+	if off > uint64(len(data)) || uint64(len(data))-off < abiWordSize {
+		return nil, fmt.Errorf("%w: word at %d out of range", ErrInvalidABI, off)
+	}
+	return data[off : off+abiWordSize], nil
+}
+
+// abiUint reads the word at off as an unsigned integer no larger than max.
+func abiUint(data []byte, off uint64, max uint64) (uint64, error) {
+	word, err := abiWord(data, off)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, fmt.Errorf("%w: integer at %d too large", ErrInvalidABI, off)
+		}
+	}
+	v := binary.BigEndian.Uint64(word[24:])
+	if v > max {
+		return 0, fmt.Errorf("%w: integer at %d too large", ErrInvalidABI, off)
+	}
+	return v, nil
+}
+
+// abiOffset reads the offset stored at off and returns it relative to base.
+func abiOffset(data []byte, off, base uint64) (uint64, error) {
+	rel, err := abiUint(data, off, uint64(len(data)))
+	if err != nil {
+		return 0, err
+	}
+	return base + rel, nil
+}
+
+// DecodeAuthorsFromABI decodes authors passed to a contract as a single
+// argument of ABI type
+//
+//	(uint8 ownerType, bytes owner, uint64 weight)[]
+//
+// where owner is the raw owner payload: the account name's bytes, the 65 byte
+// public key or the 20 byte address. data is the argument encoding; a leading
+// 4 byte function selector, as found in calldata, is skipped.
+func DecodeAuthorsFromABI(data []byte) ([]*Author, error) {
+	if len(data)%abiWordSize == 4 {
+		data = data[4:]
+	}
+	array, err := abiOffset(data, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	n, err := abiUint(data, array, uint64(len(data))/abiWordSize)
+	if err != nil {
+		return nil, err
+	}
+	base := array + abiWordSize
+	authors := make([]*Author, 0, n)
+	for i := uint64(0); i < n; i++ {
+		tuple, err := abiOffset(data, base+i*abiWordSize, base)
+		if err != nil {
+			return nil, err
+		}
+		at, err := abiUint(data, tuple, math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		ownerOff, err := abiOffset(data, tuple+abiWordSize, tuple)
+		if err != nil {
+			return nil, err
+		}
+		weight, err := abiUint(data, tuple+2*abiWordSize, math.MaxUint64)
+		if err != nil {
+			return nil, err
+		}
+		size, err := abiUint(data, ownerOff, uint64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		start := ownerOff + abiWordSize
+		if start > uint64(len(data)) || uint64(len(data))-start < size {
+			return nil, fmt.Errorf("%w: owner of author %d out of range", ErrInvalidABI, i)
+		}
+		owner, err := newOwner(AuthorType(at), data[start:start+size])
+		if err != nil {
+			return nil, fmt.Errorf("author %d: %w", i, err)
+		}
+		authors = append(authors, NewAuthor(owner, weight))
+	}
+	return authors, nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// abiAuthors is the hand encoding of
+// [(0, "alice", 1), (2, 0x00000000000000000000000000000000000000ff, 2)]
+// as (uint8,bytes,uint64)[], preceded by a function selector.
+var abiAuthors = strings.Join([]string{
+	"a1b2c3d4",
+	"0000000000000000000000000000000000000000000000000000000000000020", // array offset
+	"0000000000000000000000000000000000000000000000000000000000000002", // length
+	"0000000000000000000000000000000000000000000000000000000000000040", // tuple 0 offset
+	"00000000000000000000000000000000000000000000000000000000000000e0", // tuple 1 offset
+	"0000000000000000000000000000000000000000000000000000000000000000", // tuple 0 type
+	"0000000000000000000000000000000000000000000000000000000000000060", // owner offset
+	"0000000000000000000000000000000000000000000000000000000000000001", // weight
+	"0000000000000000000000000000000000000000000000000000000000000005", // owner length
+	"616c696365000000000000000000000000000000000000000000000000000000", // "alice"
+	"0000000000000000000000000000000000000000000000000000000000000002", // tuple 1 type
+	"0000000000000000000000000000000000000000000000000000000000000060", // owner offset
+	"0000000000000000000000000000000000000000000000000000000000000002", // weight
+	"0000000000000000000000000000000000000000000000000000000000000014", // owner length
+	"00000000000000000000000000000000000000ff000000000000000000000000", // address
+}, "")
+
+func TestDecodeAuthorsFromABI(t *testing.T) {
+	data, err := hex.DecodeString(abiAuthors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authors, err := DecodeAuthorsFromABI(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Author{NewAuthor(Name("alice"), 1), NewAuthor(BytesToAddress([]byte{0xff}), 2)}
+	if len(authors) != len(want) {
+		t.Fatalf("got %d authors, want %d", len(authors), len(want))
+	}
+	for i := range want {
+		if !authors[i].Equal(want[i]) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, authors[i].Owner, authors[i].Weight, want[i].Owner, want[i].Weight)
+		}
+	}
+
+	if _, err := DecodeAuthorsFromABI(data[:len(data)-abiWordSize]); !errors.Is(err, ErrInvalidABI) {
+		t.Errorf("truncated: got %v, want %v", err, ErrInvalidABI)
+	}
+}
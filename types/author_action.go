@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/browser/crypto"
+	"github.com/browser/rlp"
+)
+
+var ErrWouldLockAccount = errors.New("revocation would lock the account")
+
+// Apply returns the author set that results from applying the action's author
+// actions to current.
+func (aa *AccountAuthorAction) Apply(current []*Author) ([]*Author, error) {
+	return ApplyActions(current, aa.AuthorActions)
+}
+
+// Normalize returns an equivalent action holding only the net effect of aa on
+// current: no-op updates, additions that are later deleted and similar churn
+// are dropped. Thresholds are carried over unchanged.
+func (aa *AccountAuthorAction) Normalize(current []*Author) (*AccountAuthorAction, error) {
+	next, err := aa.Apply(current)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountAuthorAction{
+		Threshold:             aa.Threshold,
+		UpdateAuthorThreshold: aa.UpdateAuthorThreshold,
+		AuthorActions:         DiffAuthors(current, next),
+	}, nil
+}
+
+// ReplayBytes returns the RLP encoding of the normalized action, the minimal
+// form needed to replay aa on top of current.
+func (aa *AccountAuthorAction) ReplayBytes(current []*Author) ([]byte, error) {
+	normalized, err := aa.Normalize(current)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(normalized)
+}
+
+// CanonicalJSON returns a byte-stable JSON encoding of aa: object keys are
+// sorted at every level and author actions are ordered by owner. Actions on
+// the same owner keep their relative order, so the encoding describes the same
+// update as aa.
+func (aa *AccountAuthorAction) CanonicalJSON() ([]byte, error) {
+	for i, action := range aa.AuthorActions {
+		if action == nil {
+			return nil, fmt.Errorf("author action %d: %w", i, ErrNilAuthorAction)
+		}
+		if action.Author == nil || action.Author.Owner == nil {
+			return nil, fmt.Errorf("author action %d: %w", i, ErrInvalidOwner)
+		}
+	}
+	sorted := *aa
+	sorted.AuthorActions = make([]*AuthorAction, len(aa.AuthorActions))
+	copy(sorted.AuthorActions, aa.AuthorActions)
+	sort.SliceStable(sorted.AuthorActions, func(i, j int) bool {
+		return compareOwners(sorted.AuthorActions[i].Author.Owner, sorted.AuthorActions[j].Author.Owner) < 0
+	})
+	data, err := json.Marshal(&sorted)
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip through generic values so that every object, including those
+	// encoded from structs, is re-encoded with sorted keys.
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// RaiseThresholdPlan returns an action setting the threshold to newThreshold
+// without locking the account out. If current cannot meet newThreshold, the
+// plan first raises the weight of the lightest author by the missing amount,
+// so a single update precedes the threshold change. Reaching the threshold
+// with no authors at all would need new owners, so that case returns
+// ErrThresholdUnreachable.
+func RaiseThresholdPlan(current []*Author, newThreshold uint64) (*AccountAuthorAction, error) {
+	if newThreshold == 0 {
+		return nil, ErrZeroThreshold
+	}
+	total, err := TotalWeight(current)
+	if err != nil {
+		return nil, err
+	}
+	plan := &AccountAuthorAction{Threshold: newThreshold}
+	if total >= newThreshold {
+		return plan, nil
+	}
+	if len(current) == 0 {
+		return nil, fmt.Errorf("%w: no authors to carry threshold %d", ErrThresholdUnreachable, newThreshold)
+	}
+	lightest := current[0]
+	for _, a := range current[1:] {
+		if a.Weight < lightest.Weight || (a.Weight == lightest.Weight && compareOwners(a.Owner, lightest.Owner) < 0) {
+			lightest = a
+		}
+	}
+	plan.AuthorActions = []*AuthorAction{{
+		ActionType: UpdateAuthor,
+		Author:     NewAuthor(lightest.Owner, lightest.Weight+newThreshold-total),
+	}}
+	return plan, nil
+}
+
+// EncodeAuthorDiff returns the RLP encoding of DiffAuthors(prev, next), a
+// compact delta that ApplyAuthorDiff turns back into next.
+func EncodeAuthorDiff(prev, next []*Author) ([]byte, error) {
+	return rlp.EncodeToBytes(DiffAuthors(prev, next))
+}
+
+// ApplyAuthorDiff applies a diff produced by EncodeAuthorDiff to prev.
+func ApplyAuthorDiff(prev []*Author, diff []byte) ([]*Author, error) {
+	var actions []*AuthorAction
+	if err := rlp.DecodeBytes(diff, &actions); err != nil {
+		return nil, err
+	}
+	return ApplyActions(prev, actions)
+}
+
+// RevokeAuthors returns a DeleteAuthor-only action removing the revoked
+// owners from current. Each owner must be an author, and the remaining set
+// must still meet the account's threshold and update author threshold,
+// otherwise ErrWouldLockAccount is returned.
+func RevokeAuthors(current []*Author, revoke []Owner, threshold, updateThreshold uint64) (*AccountAuthorAction, error) {
+	revoked := make(map[string]bool, len(revoke))
+	action := &AccountAuthorAction{AuthorActions: make([]*AuthorAction, 0, len(revoke))}
+	for _, owner := range revoke {
+		key := OwnerKey(owner)
+		if revoked[key] {
+			return nil, fmt.Errorf("%w: %s revoked twice", ErrDuplicateAuthor, owner)
+		}
+		if indexOfOwner(current, owner) < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrAuthorNotFound, owner)
+		}
+		revoked[key] = true
+		action.AuthorActions = append(action.AuthorActions, &AuthorAction{ActionType: DeleteAuthor, Author: &Author{Owner: owner}})
+	}
+	remaining := make([]*Author, 0, len(current))
+	for _, a := range current {
+		if !revoked[OwnerKey(a.Owner)] {
+			remaining = append(remaining, a)
+		}
+	}
+	total, err := TotalWeight(remaining)
+	if err != nil {
+		return nil, err
+	}
+	if total < threshold || total < updateThreshold {
+		return nil, fmt.Errorf("%w: remaining weight %d, thresholds %d and %d", ErrWouldLockAccount, total, threshold, updateThreshold)
+	}
+	return action, nil
+}
+
+// ActionsCommute reports whether applying a and b to current gives the same
+// authors and thresholds in either order. If only one order applies cleanly
+// they do not commute; if neither does, the error of applying a then b is
+// returned.
+func ActionsCommute(a, b *AccountAuthorAction, current []*Author) (bool, error) {
+	apply := func(first, second *AccountAuthorAction) ([]*Author, error) {
+		authors, err := first.Apply(current)
+		if err != nil {
+			return nil, err
+		}
+		return second.Apply(authors)
+	}
+	ab, errAB := apply(a, b)
+	ba, errBA := apply(b, a)
+	switch {
+	case errAB != nil && errBA != nil:
+		return false, errAB
+	case errAB != nil || errBA != nil:
+		return false, nil
+	}
+	later := func(first, second uint64) uint64 {
+		if second != 0 {
+			return second
+		}
+		return first
+	}
+	if later(a.Threshold, b.Threshold) != later(b.Threshold, a.Threshold) ||
+		later(a.UpdateAuthorThreshold, b.UpdateAuthorThreshold) != later(b.UpdateAuthorThreshold, a.UpdateAuthorThreshold) {
+		return false, nil
+	}
+	return AuthorsEqual(ab, ba), nil
+}
+
+// LogicalID identifies the intent of aa on current: the hash of its
+// normalized net effect and thresholds. Submissions that reorder or pad the
+// same change share an ID, so pending duplicates can be dropped. An action
+// that does not apply to current has no ID and its error is returned.
+func (aa *AccountAuthorAction) LogicalID(current []*Author) (Hash, error) {
+	normalized, err := aa.Normalize(current)
+	if err != nil {
+		return Hash{}, err
+	}
+	enc, err := rlp.EncodeToBytes(normalized)
+	if err != nil {
+		return Hash{}, err
+	}
+	return BytesToHash(crypto.Keccak256(enc)), nil
+}
@@ -0,0 +1,262 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/browser/rlp"
+)
+
+func TestReplayBytes(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 2),
+	}
+	aa := &AccountAuthorAction{
+		AuthorActions: []*AuthorAction{
+			{ActionType: AddAuthor, Author: NewAuthor(Name("carol"), 1)},
+			{ActionType: UpdateAuthor, Author: NewAuthor(Name("alice"), 1)},
+			{ActionType: UpdateAuthor, Author: NewAuthor(Name("bob"), 5)},
+			{ActionType: UpdateAuthor, Author: NewAuthor(Name("bob"), 2)},
+			{ActionType: DeleteAuthor, Author: NewAuthor(Name("carol"), 0)},
+			{ActionType: AddAuthor, Author: NewAuthor(Name("dave"), 3)},
+		},
+	}
+	full, err := rlp.EncodeToBytes(aa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replay, err := aa.ReplayBytes(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replay) >= len(full)/3 {
+		t.Errorf("replay bytes too large: got %d, full action is %d", len(replay), len(full))
+	}
+
+	decoded := new(AccountAuthorAction)
+	if err := rlp.DecodeBytes(replay, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.AuthorActions) != 1 {
+		t.Fatalf("got %d replay actions, want 1", len(decoded.AuthorActions))
+	}
+	want, err := aa.Apply(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decoded.Apply(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !AuthorsEqual(got, want) {
+		t.Errorf("replayed authors differ from applying the full action")
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	add := func(owner Owner, weight uint64) *AuthorAction {
+		return &AuthorAction{ActionType: AddAuthor, Author: NewAuthor(owner, weight)}
+	}
+	pubKey := HexToPubKey(testPubKeyHex)
+	a := &AccountAuthorAction{
+		Threshold:     2,
+		AuthorActions: []*AuthorAction{add(Name("bob"), 1), add(pubKey, 2), add(Name("alice"), 1)},
+	}
+	b := &AccountAuthorAction{
+		AuthorActions: []*AuthorAction{add(Name("alice"), 1), add(pubKey, 2), add(Name("bob"), 1)},
+	}
+	b.Threshold = 2
+
+	aj, err := a.CanonicalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bj, err := b.CanonicalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(aj) != string(bj) {
+		t.Errorf("canonical JSON differs:\n%s\n%s", aj, bj)
+	}
+	want := `{"authorActions":[{"ActionType":0,"Author":{"owner":"alice","weight":1}},`
+	if len(aj) < len(want) || string(aj[:len(want)]) != want {
+		t.Errorf("got %s, want prefix %s", aj, want)
+	}
+}
+
+func TestRaiseThresholdPlan(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	plan, err := RaiseThresholdPlan(current, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Threshold != 4 || len(plan.AuthorActions) != 0 {
+		t.Errorf("reachable threshold: got %+v, want threshold only", plan)
+	}
+
+	plan, err = RaiseThresholdPlan(current, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.AuthorActions) != 1 {
+		t.Fatalf("got %d actions, want 1", len(plan.AuthorActions))
+	}
+	update := plan.AuthorActions[0]
+	if update.ActionType != UpdateAuthor || !update.Author.Equal(NewAuthor(Name("bob"), 3)) {
+		t.Errorf("got %d %v/%d, want update of bob to 3", update.ActionType, update.Author.Owner, update.Author.Weight)
+	}
+	if _, err := ValidateAuthorization(current, plan, ValidateOptions{UpdateAuthorThreshold: 1}); err != nil {
+		t.Errorf("plan does not validate: %v", err)
+	}
+
+	if _, err := RaiseThresholdPlan(nil, 1); !errors.Is(err, ErrThresholdUnreachable) {
+		t.Errorf("no authors: got %v, want %v", err, ErrThresholdUnreachable)
+	}
+}
+
+func TestAuthorDiffRoundTrip(t *testing.T) {
+	prev := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 2),
+		NewAuthor(HexToPubKey(testPubKeyHex), 1),
+	}
+	next := []*Author{
+		NewAuthor(HexToPubKey(testPubKeyHex), 3),
+		NewAuthor(Name("carol"), 1),
+		NewAuthor(Name("alice"), 1),
+	}
+	diff, err := EncodeAuthorDiff(prev, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ApplyAuthorDiff(prev, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !AuthorsEqual(got, next) {
+		t.Errorf("applied diff does not reproduce next")
+	}
+
+	empty, err := EncodeAuthorDiff(prev, prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty) != 1 {
+		t.Errorf("diff of identical sets is %d bytes, want 1", len(empty))
+	}
+}
+
+func TestRevokeAuthors(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	action, err := RevokeAuthors(current, []Owner{Name("bob")}, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(action.AuthorActions) != 1 || action.AuthorActions[0].ActionType != DeleteAuthor {
+		t.Fatalf("got %+v, want a single delete", action.AuthorActions)
+	}
+	remaining, err := action.Apply(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !AuthorsEqual(remaining, []*Author{current[0], current[2]}) {
+		t.Errorf("bob not revoked")
+	}
+
+	if _, err := RevokeAuthors(current, []Owner{Name("bob"), Name("carol")}, 2, 3); !errors.Is(err, ErrWouldLockAccount) {
+		t.Errorf("lock out: got %v, want %v", err, ErrWouldLockAccount)
+	}
+	if _, err := RevokeAuthors(current, []Owner{Name("dave")}, 1, 1); !errors.Is(err, ErrAuthorNotFound) {
+		t.Errorf("missing owner: got %v, want %v", err, ErrAuthorNotFound)
+	}
+}
+
+func TestActionsCommute(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 1),
+	}
+	update := func(name string, weight uint64) *AccountAuthorAction {
+		return &AccountAuthorAction{AuthorActions: []*AuthorAction{{ActionType: UpdateAuthor, Author: NewAuthor(Name(name), weight)}}}
+	}
+	tests := []struct {
+		name string
+		a, b *AccountAuthorAction
+		want bool
+	}{
+		{"disjoint owners", update("alice", 2), update("bob", 3), true},
+		{"same owner", update("alice", 2), update("alice", 3), false},
+		{"same update", update("alice", 2), update("alice", 2), true},
+		{"conflicting thresholds", &AccountAuthorAction{Threshold: 1}, &AccountAuthorAction{Threshold: 2}, false},
+		{"one threshold", &AccountAuthorAction{Threshold: 2}, update("bob", 3), true},
+		{
+			"add then update",
+			&AccountAuthorAction{AuthorActions: []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("carol"), 1)}}},
+			update("carol", 2),
+			false,
+		},
+	}
+	for _, test := range tests {
+		got, err := ActionsCommute(test.a, test.b, current)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	if _, err := ActionsCommute(update("dave", 1), update("erin", 1), current); !errors.Is(err, ErrAuthorNotFound) {
+		t.Errorf("neither order applies: got %v, want %v", err, ErrAuthorNotFound)
+	}
+}
+
+func TestLogicalID(t *testing.T) {
+	current := []*Author{NewAuthor(Name("alice"), 1), NewAuthor(Name("bob"), 1)}
+	update := NewAuthor(Name("alice"), 2)
+	add := NewAuthor(Name("carol"), 1)
+	id := func(aa *AccountAuthorAction) Hash {
+		h, err := aa.LogicalID(current)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	a := &AccountAuthorAction{Threshold: 2, AuthorActions: []*AuthorAction{
+		{ActionType: UpdateAuthor, Author: update},
+		{ActionType: AddAuthor, Author: add},
+	}}
+	b := &AccountAuthorAction{Threshold: 2, AuthorActions: []*AuthorAction{
+		{ActionType: AddAuthor, Author: add},
+		{ActionType: UpdateAuthor, Author: NewAuthor(Name("bob"), 1)},
+		{ActionType: UpdateAuthor, Author: update},
+	}}
+	if id(a) != id(b) {
+		t.Errorf("equivalent actions have different logical IDs")
+	}
+
+	c := &AccountAuthorAction{Threshold: 3, AuthorActions: a.AuthorActions}
+	if id(a) == id(c) {
+		t.Errorf("actions with different thresholds share a logical ID")
+	}
+	d := &AccountAuthorAction{Threshold: 2, AuthorActions: a.AuthorActions[:1]}
+	if id(a) == id(d) {
+		t.Errorf("actions with different effects share a logical ID")
+	}
+
+	nilOwner := &AccountAuthorAction{AuthorActions: []*AuthorAction{{ActionType: AddAuthor, Author: &Author{Weight: 1}}}}
+	if _, err := nilOwner.LogicalID(current); !errors.Is(err, ErrInvalidOwner) {
+		t.Errorf("nil owner: got %v, want %v", err, ErrInvalidOwner)
+	}
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/browser/crypto"
+	"github.com/browser/rlp"
+)
+
+var (
+	ErrAttestationHash      = errors.New("attestation hash does not match author set")
+	ErrAttestationSignature = errors.New("invalid attestation signature")
+)
+
+// AuthorAttestation is a signed snapshot of an account's control set.
+type AuthorAttestation struct {
+	Authors   []*Author `json:"authors"` // in canonical order
+	Threshold uint64    `json:"threshold"`
+	Hash      Hash      `json:"hash"`
+	Signature []byte    `json:"signature"`
+}
+
+// attestationHash is the Keccak-256 hash of the RLP encoding of the sorted
+// authors and threshold.
+func attestationHash(authors []*Author, threshold uint64) (Hash, error) {
+	sorted := copyAuthors(authors)
+	SortAuthors(sorted)
+	enc, err := rlp.EncodeToBytes([]interface{}{sorted, threshold})
+	if err != nil {
+		return Hash{}, err
+	}
+	return BytesToHash(crypto.Keccak256(enc)), nil
+}
+
+// AttestAuthorSet canonicalizes authors, hashes them together with threshold
+// and signs the hash with signFn, which must return a secp256k1 signature in
+// the [R || S || V] format produced by crypto.Sign.
+func AttestAuthorSet(authors []*Author, threshold uint64, signFn func([]byte) ([]byte, error)) (*AuthorAttestation, error) {
+	hash, err := attestationHash(authors, threshold)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signFn(hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	att := &AuthorAttestation{Authors: copyAuthors(authors), Threshold: threshold, Hash: hash, Signature: sig}
+	SortAuthors(att.Authors)
+	return att, nil
+}
+
+// VerifyAuthorAttestation checks that att's hash matches its authors and
+// threshold and that it was signed by pubkey.
+func VerifyAuthorAttestation(att *AuthorAttestation, pubkey PubKey) error {
+	hash, err := attestationHash(att.Authors, att.Threshold)
+	if err != nil {
+		return err
+	}
+	if hash != att.Hash {
+		return ErrAttestationHash
+	}
+	if len(att.Signature) < 64 || !crypto.VerifySignature(pubkey.Bytes(), hash.Bytes(), att.Signature[:64]) {
+		return ErrAttestationSignature
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+func TestAuthorAttestation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := BytesToPubKey(crypto.FromECDSAPub(&key.PublicKey))
+	authors := []*Author{
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(pubKey, 2),
+		NewAuthor(Name("alice"), 1),
+	}
+	att, err := AttestAuthorSet(authors, 2, func(hash []byte) ([]byte, error) {
+		return crypto.Sign(hash, key)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAuthorAttestation(att, pubKey); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if att.Authors[0].Owner != Name("alice") {
+		t.Errorf("attested authors not in canonical order")
+	}
+
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyAuthorAttestation(att, BytesToPubKey(crypto.FromECDSAPub(&other.PublicKey))); !errors.Is(err, ErrAttestationSignature) {
+		t.Errorf("wrong key: got %v, want %v", err, ErrAttestationSignature)
+	}
+	att.Threshold = 1
+	if err := VerifyAuthorAttestation(att, pubKey); !errors.Is(err, ErrAttestationHash) {
+		t.Errorf("tampered threshold: got %v, want %v", err, ErrAttestationHash)
+	}
+}
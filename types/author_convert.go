@@ -0,0 +1,37 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnsupportedConversion = errors.New("unsupported author conversion")
+
+// ConvertAuthors converts every author to an owner of type target, keeping
+// weights. The only supported conversion is pubkey to address, derived with d
+// (DefaultAddressDeriver if nil); authors already of type target are kept as
+// is. Any other author makes the whole conversion fail with
+// ErrUnsupportedConversion.
+func ConvertAuthors(authors []*Author, target AuthorType, d AddressDeriver) ([]*Author, error) {
+	if d == nil {
+		d = DefaultAddressDeriver
+	}
+	converted := make([]*Author, 0, len(authors))
+	for i, a := range authors {
+		at, _ := OwnerType(a.Owner)
+		switch {
+		case at == target:
+			converted = append(converted, &Author{Owner: a.Owner, Weight: a.Weight})
+		case at == PubKeyType && target == AddressType:
+			address, err := d.Derive(a.Owner.(PubKey))
+			if err != nil {
+				return nil, fmt.Errorf("author %d: %v", i, err)
+			}
+			converted = append(converted, &Author{Owner: address, Weight: a.Weight})
+		default:
+			return nil, fmt.Errorf("author %d: %w: %s to %s", i, ErrUnsupportedConversion,
+				authorTypeName(at), authorTypeName(target))
+		}
+	}
+	return converted, nil
+}
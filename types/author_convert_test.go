@@ -0,0 +1,39 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+func TestConvertAuthors(t *testing.T) {
+	var authors, want []*Author
+	for i := uint64(1); i <= 3; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		authors = append(authors, NewAuthor(BytesToPubKey(crypto.FromECDSAPub(&key.PublicKey)), i))
+		want = append(want, NewAuthor(BytesToAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes()), i))
+	}
+	authors = append(authors, want[0])
+	want = append(want, want[0])
+
+	got, err := ConvertAuthors(authors, AddressType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, got[i].Owner, got[i].Weight, want[i].Owner, want[i].Weight)
+		}
+	}
+
+	if _, err := ConvertAuthors(want, PubKeyType, nil); !errors.Is(err, ErrUnsupportedConversion) {
+		t.Errorf("address to pubkey: got %v, want %v", err, ErrUnsupportedConversion)
+	}
+	if _, err := ConvertAuthors([]*Author{NewAuthor(Name("alice"), 1)}, AddressType, nil); !errors.Is(err, ErrUnsupportedConversion) {
+		t.Errorf("name to address: got %v, want %v", err, ErrUnsupportedConversion)
+	}
+}
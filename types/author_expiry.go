@@ -0,0 +1,51 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExpiringAuthor pairs an author with the time its key is meant to be
+// rotated out. The expiry is bookkeeping only: it is never part of the
+// author's RLP encoding and has no effect on consensus.
+type ExpiringAuthor struct {
+	Author    *Author   `json:"author"`
+	ExpiresAt time.Time `json:"expiresAt"` // zero if the author never expires
+}
+
+// UnmarshalJSON decodes e, inferring the author's owner type with InferOwner
+// unless the author carries an explicit "type".
+func (e *ExpiringAuthor) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Author    *rawAuthorJSON `json:"author"`
+		ExpiresAt time.Time      `json:"expiresAt"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Author, e.ExpiresAt = nil, raw.ExpiresAt
+	if raw.Author != nil {
+		a, err := raw.Author.author()
+		if err != nil {
+			return err
+		}
+		e.Author = a
+	}
+	return nil
+}
+
+// IsExpired reports whether e has expired at now.
+func (e *ExpiringAuthor) IsExpired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// FilterExpired returns the authors that have not expired at now.
+func FilterExpired(authors []*ExpiringAuthor, now time.Time) []*ExpiringAuthor {
+	live := make([]*ExpiringAuthor, 0, len(authors))
+	for _, e := range authors {
+		if !e.IsExpired(now) {
+			live = append(live, e)
+		}
+	}
+	return live
+}
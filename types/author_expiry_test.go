@@ -0,0 +1,73 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/browser/rlp"
+)
+
+func TestFilterExpired(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	authors := []*ExpiringAuthor{
+		{Author: NewAuthor(Name("alice"), 1)},
+		{Author: NewAuthor(Name("bob"), 1), ExpiresAt: now.Add(-time.Hour)},
+		{Author: NewAuthor(Name("carol"), 1), ExpiresAt: now},
+		{Author: NewAuthor(Name("dave"), 1), ExpiresAt: now.Add(time.Hour)},
+	}
+	live := FilterExpired(authors, now)
+	if len(live) != 2 || live[0].Author.Owner != Name("alice") || live[1].Author.Owner != Name("dave") {
+		t.Errorf("got %d live authors, want alice and dave", len(live))
+	}
+}
+
+func TestExpiringAuthorEncoding(t *testing.T) {
+	a := NewAuthor(Name("alice"), 2)
+	e := &ExpiringAuthor{Author: a, ExpiresAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"author":{"owner":"alice","weight":2},"expiresAt":"2020-01-01T00:00:00Z"}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+	decoded := new(ExpiringAuthor)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.ExpiresAt.Equal(e.ExpiresAt) || !decoded.Author.Equal(a) {
+		t.Errorf("got %v/%v, want %v/%v", decoded.Author.Owner, decoded.ExpiresAt, a.Owner, e.ExpiresAt)
+	}
+
+	for _, a := range []*Author{
+		NewAuthor(HexToPubKey(testPubKeyHex), 3),
+		NewAuthor(BytesToAddress(FromHex("0x9858effd232b4033e47d90003d41ec34ecaeda94")), 4),
+	} {
+		data, err := json.Marshal(&ExpiringAuthor{Author: a, ExpiresAt: e.ExpiresAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := new(ExpiringAuthor)
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Author.Equal(a) {
+			t.Errorf("%s: round trip gave %T %v", data, decoded.Author.Owner, decoded.Author.Owner)
+		}
+	}
+
+	plain, err := rlp.EncodeToBytes(NewAuthor(Name("alice"), 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapped, err := rlp.EncodeToBytes(e.Author)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plain, wrapped) {
+		t.Errorf("wrapped author encodes as %x, want %x", wrapped, plain)
+	}
+}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// maxTableOwnerLength is the width owners are truncated to in
+// FormatAuthorsTable.
+const maxTableOwnerLength = 20
+
+func truncateOwner(s string) string {
+	if len(s) <= maxTableOwnerLength {
+		return s
+	}
+	half := (maxTableOwnerLength - 3) / 2
+	return s[:half] + "..." + s[len(s)-half:]
+}
+
+// FormatAuthorsTable renders authors as an aligned text table of type, owner,
+// weight and share of the total weight, followed by a footer with the total
+// weight and threshold.
+func FormatAuthorsTable(authors []*Author, threshold uint64) string {
+	total, err := TotalWeight(authors)
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tOWNER\tWEIGHT\tSHARE")
+	for _, a := range authors {
+		at, _ := OwnerType(a.Owner)
+		share := 0.0
+		if total != 0 {
+			share = float64(a.Weight) / float64(total) * 100
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.2f%%\n", authorTypeName(at), truncateOwner(a.Owner.String()), a.Weight, share)
+	}
+	w.Flush()
+	if err != nil {
+		fmt.Fprintf(&buf, "total weight: overflow, threshold: %d\n", threshold)
+	} else {
+		fmt.Fprintf(&buf, "total weight: %d, threshold: %d\n", total, threshold)
+	}
+	return buf.String()
+}
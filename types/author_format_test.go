@@ -0,0 +1,32 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatAuthorsTable(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(HexToPubKey(testPubKeyHex), 2),
+		NewAuthor(BytesToAddress([]byte{1}), 1),
+	}
+	lines := strings.Split(strings.TrimRight(FormatAuthorsTable(authors, 3), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want 5:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for _, column := range []string{"OWNER", "WEIGHT", "SHARE"} {
+		col := strings.Index(lines[0], column)
+		for _, line := range lines[1:4] {
+			if col >= len(line) || line[col-1] != ' ' || line[col] == ' ' {
+				t.Errorf("column %s not aligned at %d in %q", column, col, line)
+			}
+		}
+	}
+	if !strings.Contains(lines[2], "50.00%") {
+		t.Errorf("pubkey row %q missing 50.00%% share", lines[2])
+	}
+	if lines[4] != "total weight: 4, threshold: 3" {
+		t.Errorf("got footer %q", lines[4])
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/browser/rlp"
+)
+
+// MaxFramedAuthorSize bounds the frame length ReadFramedAuthor accepts, well
+// above the size of any valid author.
+const MaxFramedAuthorSize = 4096
+
+var ErrFrameTooLarge = errors.New("author frame too large")
+
+// WriteFramedAuthor writes a to w as a 4-byte big-endian length followed by
+// the author's RLP encoding.
+func WriteFramedAuthor(w io.Writer, a *Author) error {
+	enc, err := rlp.EncodeToBytes(a)
+	if err != nil {
+		return err
+	}
+	if len(enc) > MaxFramedAuthorSize {
+		return fmt.Errorf("%w: %d bytes", ErrFrameTooLarge, len(enc))
+	}
+	frame := make([]byte, 4+len(enc))
+	binary.BigEndian.PutUint32(frame, uint32(len(enc)))
+	copy(frame[4:], enc)
+	_, err = w.Write(frame)
+	return err
+}
+
+// ReadFramedAuthor reads an author written by WriteFramedAuthor from r.
+func ReadFramedAuthor(r io.Reader) (*Author, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > MaxFramedAuthorSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrFrameTooLarge, size)
+	}
+	enc := make([]byte, size)
+	if _, err := io.ReadFull(r, enc); err != nil {
+		return nil, err
+	}
+	a := new(Author)
+	if err := rlp.DecodeBytes(enc, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestFramedAuthorPipe(t *testing.T) {
+	authors := GenerateTestAuthors(6, 7)
+	r, w := io.Pipe()
+	go func() {
+		for _, a := range authors {
+			if err := WriteFramedAuthor(w, a); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+		w.Close()
+	}()
+	for i, want := range authors {
+		got, err := ReadFramedAuthor(r)
+		if err != nil {
+			t.Fatalf("author %d: %v", i, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, got.Owner, got.Weight, want.Owner, want.Weight)
+		}
+	}
+	if _, err := ReadFramedAuthor(r); err != io.EOF {
+		t.Errorf("after last frame: got %v, want EOF", err)
+	}
+}
+
+func TestReadFramedAuthorTooLarge(t *testing.T) {
+	frame := []byte{0xff, 0xff, 0xff, 0xff}
+	if _, err := ReadFramedAuthor(bytes.NewReader(frame)); !errors.Is(err, ErrFrameTooLarge) {
+		t.Errorf("got %v, want %v", err, ErrFrameTooLarge)
+	}
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"sort"
+)
+
+// WeightPoint is an owner's weight after one action of an account's history.
+type WeightPoint struct {
+	Action int    `json:"action"` // index of the action in the history
+	Weight uint64 `json:"weight"` // zero while the owner is not an author
+}
+
+// WeightTimeline replays actions on top of initial and returns, keyed by
+// OwnerKey, every owner's weight after each action. Each owner that is an
+// author in initial or after any action gets one WeightPoint per action. An
+// action that is nil or fails to apply leaves the author set unchanged, as
+// the node would reject it.
+func WeightTimeline(actions []*AccountAuthorAction, initial []*Author) map[string][]WeightPoint {
+	timeline := make(map[string][]WeightPoint, len(initial))
+	for _, a := range initial {
+		timeline[OwnerKey(a.Owner)] = make([]WeightPoint, 0, len(actions))
+	}
+	authors := initial
+	for i, aa := range actions {
+		if aa != nil {
+			if next, err := aa.Apply(authors); err == nil {
+				authors = next
+			}
+		}
+		weights := make(map[string]uint64, len(authors))
+		for _, a := range authors {
+			key := OwnerKey(a.Owner)
+			weights[key] = a.Weight
+			if _, ok := timeline[key]; !ok {
+				timeline[key] = make([]WeightPoint, i)
+				for j := range timeline[key] {
+					timeline[key][j].Action = j
+				}
+			}
+		}
+		for key := range timeline {
+			timeline[key] = append(timeline[key], WeightPoint{Action: i, Weight: weights[key]})
+		}
+	}
+	return timeline
+}
+
+// DetectWeightInflation returns, sorted, the OwnerKeys of owners whose final
+// weight after replaying actions on initial exceeds growthFactor times their
+// starting weight. An owner's starting weight is its weight in initial, or
+// the weight it was first added with.
+func DetectWeightInflation(actions []*AccountAuthorAction, initial []*Author, growthFactor float64) []string {
+	start := make(map[string]uint64, len(initial))
+	for _, a := range initial {
+		start[OwnerKey(a.Owner)] = a.Weight
+	}
+	inflated := make([]string, 0)
+	for key, points := range WeightTimeline(actions, initial) {
+		if len(points) == 0 {
+			continue
+		}
+		base := start[key]
+		for _, p := range points {
+			if base != 0 {
+				break
+			}
+			base = p.Weight
+		}
+		final := points[len(points)-1].Weight
+		if base != 0 && float64(final) > float64(base)*growthFactor {
+			inflated = append(inflated, key)
+		}
+	}
+	sort.Strings(inflated)
+	return inflated
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWeightTimeline(t *testing.T) {
+	initial := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 1),
+	}
+	actions := []*AccountAuthorAction{
+		{AuthorActions: []*AuthorAction{{ActionType: UpdateAuthor, Author: NewAuthor(Name("alice"), 2)}}},
+		{AuthorActions: []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("carol"), 3)}}},
+		{AuthorActions: []*AuthorAction{{ActionType: DeleteAuthor, Author: NewAuthor(Name("bob"), 0)}}},
+		{AuthorActions: []*AuthorAction{{ActionType: DeleteAuthor, Author: NewAuthor(Name("dave"), 0)}}},
+	}
+	timeline := WeightTimeline(actions, initial)
+
+	want := map[string][]uint64{
+		OwnerKey(Name("alice")): {2, 2, 2, 2},
+		OwnerKey(Name("bob")):   {1, 1, 0, 0},
+		OwnerKey(Name("carol")): {0, 3, 3, 3},
+	}
+	if len(timeline) != len(want) {
+		t.Fatalf("got %d owners, want %d", len(timeline), len(want))
+	}
+	for key, weights := range want {
+		var got []uint64
+		for i, p := range timeline[key] {
+			if p.Action != i {
+				t.Errorf("%s: point %d has action %d", key, i, p.Action)
+			}
+			got = append(got, p.Weight)
+		}
+		if !reflect.DeepEqual(got, weights) {
+			t.Errorf("%s: got weights %v, want %v", key, got, weights)
+		}
+	}
+}
+
+func TestDetectWeightInflation(t *testing.T) {
+	initial := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 2),
+		NewAuthor(Name("carol"), 2),
+	}
+	update := func(name string, weight uint64) *AccountAuthorAction {
+		return &AccountAuthorAction{AuthorActions: []*AuthorAction{{ActionType: UpdateAuthor, Author: NewAuthor(Name(name), weight)}}}
+	}
+	actions := []*AccountAuthorAction{
+		update("alice", 2),
+		update("bob", 3),
+		update("alice", 3),
+		update("bob", 2),
+		{AuthorActions: []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("dave"), 1)}}},
+		update("dave", 2),
+	}
+	got := DetectWeightInflation(actions, initial, 2)
+	want := []string{OwnerKey(Name("alice"))}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := DetectWeightInflation(actions, initial, 1.5); len(got) != 2 {
+		t.Errorf("factor 1.5: got %v, want alice and dave", got)
+	}
+}
+
+func TestWeightTimelineDeletesInitialOwner(t *testing.T) {
+	initial := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 2),
+	}
+	actions := []*AccountAuthorAction{
+		{AuthorActions: []*AuthorAction{{ActionType: DeleteAuthor, Author: NewAuthor(Name("alice"), 0)}}},
+	}
+	want := map[string][]WeightPoint{
+		OwnerKey(Name("alice")): {{Action: 0, Weight: 0}},
+		OwnerKey(Name("bob")):   {{Action: 0, Weight: 2}},
+	}
+	if got := WeightTimeline(actions, initial); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	want[OwnerKey(Name("alice"))] = append(want[OwnerKey(Name("alice"))], WeightPoint{Action: 1})
+	want[OwnerKey(Name("bob"))] = append(want[OwnerKey(Name("bob"))], WeightPoint{Action: 1, Weight: 2})
+	if got := WeightTimeline(append(actions, nil), initial); !reflect.DeepEqual(got, want) {
+		t.Errorf("nil action: got %v, want %v", got, want)
+	}
+
+	if got := DetectWeightInflation(nil, initial, 1); len(got) != 0 {
+		t.Errorf("no actions: got %v, want none inflated", got)
+	}
+}
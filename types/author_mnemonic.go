@@ -0,0 +1,68 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/browser/crypto"
+	"github.com/browser/crypto/hd"
+)
+
+var (
+	ErrInvalidDerivationPath = hd.ErrInvalidPath
+	ErrNoWordlist            = errors.New("no BIP-39 wordlist to check the mnemonic against")
+)
+
+// KeyDeriver derives the public key at a derivation path from a mnemonic.
+type KeyDeriver interface {
+	DeriveKey(mnemonic, path string) (PubKey, error)
+}
+
+// BIP44Deriver derives keys by checking the mnemonic against Wordlist, turning
+// it into a seed as in BIP-39 and walking a BIP-32 path such as
+// "m/44'/60'/0'/0/0" from it. Without a wordlist it derives nothing, since a
+// mistyped phrase would otherwise yield a valid key that nobody holds.
+type BIP44Deriver struct {
+	Wordlist   *hd.Wordlist // e.g. the BIP-39 English list, see hd.LoadWordlist
+	Passphrase string       // optional BIP-39 passphrase
+}
+
+// DefaultKeyDeriver is the deriver used by AuthorFromMnemonic. It has no
+// wordlist until one is configured.
+var DefaultKeyDeriver KeyDeriver = BIP44Deriver{}
+
+// AuthorFromMnemonic returns a pubkey author for the key DefaultKeyDeriver
+// derives from mnemonic at path.
+func AuthorFromMnemonic(mnemonic, path string, weight uint64) (*Author, error) {
+	pubKey, err := DefaultKeyDeriver.DeriveKey(mnemonic, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthor(pubKey, weight), nil
+}
+
+// DeriveKey implements KeyDeriver.
+func (d BIP44Deriver) DeriveKey(mnemonic, path string) (PubKey, error) {
+	if d.Wordlist == nil {
+		return PubKey{}, ErrNoWordlist
+	}
+	if err := d.Wordlist.ValidateMnemonic(mnemonic); err != nil {
+		return PubKey{}, err
+	}
+	indexes, err := hd.ParsePath(path)
+	if err != nil {
+		return PubKey{}, err
+	}
+	master, err := hd.NewMasterKey(hd.NewSeed(mnemonic, d.Passphrase))
+	if err != nil {
+		return PubKey{}, err
+	}
+	key, err := master.Derive(indexes)
+	if err != nil {
+		return PubKey{}, err
+	}
+	priv, err := key.PrivateKey()
+	if err != nil {
+		return PubKey{}, err
+	}
+	return BytesToPubKey(crypto.FromECDSAPub(&priv.PublicKey)), nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/browser/crypto/hd"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// testMnemonicWordlist returns a wordlist holding the words of testMnemonic
+// at their BIP-39 indexes and placeholders elsewhere.
+func testMnemonicWordlist(t *testing.T) *hd.Wordlist {
+	words := make([]string, hd.WordlistSize)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%04d", i)
+	}
+	words[0], words[3] = "abandon", "about"
+	w, err := hd.NewWordlist(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func TestAuthorFromMnemonic(t *testing.T) {
+	defer func(d KeyDeriver) { DefaultKeyDeriver = d }(DefaultKeyDeriver)
+	DefaultKeyDeriver = BIP44Deriver{Wordlist: testMnemonicWordlist(t)}
+
+	a, err := AuthorFromMnemonic(testMnemonic, "m/44'/60'/0'/0/0", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := PubKeyToAddress(a.Owner.(PubKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"; address.Hex() != want || a.Weight != 2 {
+		t.Errorf("got %s/%d, want %s/2", address.Hex(), a.Weight, want)
+	}
+
+	for _, path := range []string{"44'/60'", "m/x", "m/2147483648"} {
+		if _, err := AuthorFromMnemonic(testMnemonic, path, 1); !errors.Is(err, ErrInvalidDerivationPath) {
+			t.Errorf("%q: got %v, want %v", path, err, ErrInvalidDerivationPath)
+		}
+	}
+
+	mistyped := []struct {
+		mnemonic string
+		err      error
+	}{
+		{strings.Repeat("abandon ", 12), hd.ErrMnemonicChecksum},
+		{strings.Repeat("abandon ", 11) + "abuot", hd.ErrUnknownWord},
+		{strings.Repeat("abandon ", 10) + "about", hd.ErrMnemonicLength},
+	}
+	for _, test := range mistyped {
+		if _, err := AuthorFromMnemonic(test.mnemonic, "m/44'/60'/0'/0/0", 1); !errors.Is(err, test.err) {
+			t.Errorf("%q: got %v, want %v", test.mnemonic, err, test.err)
+		}
+	}
+
+	DefaultKeyDeriver = BIP44Deriver{}
+	if _, err := AuthorFromMnemonic(testMnemonic, "m/44'/60'/0'/0/0", 1); !errors.Is(err, ErrNoWordlist) {
+		t.Errorf("no wordlist: got %v, want %v", err, ErrNoWordlist)
+	}
+}
+
+type fixedKeyDeriver PubKey
+
+func (d fixedKeyDeriver) DeriveKey(mnemonic, path string) (PubKey, error) { return PubKey(d), nil }
+
+func TestCustomKeyDeriver(t *testing.T) {
+	defer func(d KeyDeriver) { DefaultKeyDeriver = d }(DefaultKeyDeriver)
+	key := HexToPubKey(testPubKeyHex)
+	DefaultKeyDeriver = fixedKeyDeriver(key)
+	a, err := AuthorFromMnemonic(testMnemonic, "m/0", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Owner != key {
+		t.Errorf("got %v, want %v", a.Owner, key)
+	}
+}
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/browser/rlp"
+)
+
+var ErrInvalidLinkToken = errors.New("invalid author link token")
+
+// AuthorFromValues builds an author from query parameters of the form
+// ?type=pubkey&owner=0x...&weight=2. The weight defaults to 1 when absent.
+func AuthorFromValues(v url.Values) (*Author, error) {
+	at, err := ParseAuthorType(v.Get("type"))
+	if err != nil {
+		return nil, err
+	}
+	ownerStr := v.Get("owner")
+	if len(ownerStr) == 0 {
+		return nil, fmt.Errorf("%w: missing owner", ErrInvalidOwner)
+	}
+	owner, err := GenerateOwnerE(ownerStr, at)
+	if err != nil {
+		return nil, err
+	}
+	weight := uint64(1)
+	if w := v.Get("weight"); len(w) != 0 {
+		if weight, err = parseWeight(w); err != nil {
+			return nil, err
+		}
+	}
+	return NewAuthor(owner, weight), nil
+}
+
+func parseWeight(s string) (uint64, error) {
+	weight, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid author weight %q: %v", s, err)
+	}
+	return weight, nil
+}
+
+// ParseOwnerURI parses an owner written as <type>:<owner>, e.g.
+// "pubkey:0x04ab..." or "account:alice". Pubkeys are accepted in any form
+// NormalizePubKeyHex accepts.
+func ParseOwnerURI(s string) (Owner, error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q has no type", ErrInvalidOwner, s)
+	}
+	at, err := ParseAuthorType(s[:i])
+	if err != nil {
+		return nil, err
+	}
+	return GenerateOwnerE(s[i+1:], at)
+}
+
+// ParseAuthorText parses an author in the compact text form
+// <type>:<owner>[@<weight>], e.g. "pubkey:0x04ab...@2". The weight defaults
+// to 1 when absent.
+func ParseAuthorText(s string) (*Author, error) {
+	s = strings.TrimSpace(s)
+	weight := uint64(1)
+	if i := strings.LastIndexByte(s, '@'); i >= 0 {
+		var err error
+		if weight, err = parseWeight(s[i+1:]); err != nil {
+			return nil, err
+		}
+		s = s[:i]
+	}
+	owner, err := ParseOwnerURI(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthor(owner, weight), nil
+}
+
+// AuthorsFromEnv reads authors in the compact text form from the environment
+// variables PREFIX_AUTHOR_0, PREFIX_AUTHOR_1, ... up to the first missing
+// index.
+func AuthorsFromEnv(prefix string) ([]*Author, error) {
+	authors := make([]*Author, 0)
+	for i := 0; ; i++ {
+		name := fmt.Sprintf("%s_AUTHOR_%d", prefix, i)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return authors, nil
+		}
+		a, err := ParseAuthorText(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		authors = append(authors, a)
+	}
+}
+
+// LinkToken returns a URL-safe token for a: the unpadded base64url encoding of
+// its RLP form.
+func (a *Author) LinkToken() (string, error) {
+	enc, err := rlp.EncodeToBytes(a)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(enc), nil
+}
+
+// AuthorFromLinkToken decodes an author from a token made by LinkToken.
+func AuthorFromLinkToken(tok string) (*Author, error) {
+	enc, err := base64.RawURLEncoding.DecodeString(tok)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLinkToken, err)
+	}
+	a := new(Author)
+	if err := rlp.DecodeBytes(enc, a); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLinkToken, err)
+	}
+	return a, nil
+}
+
+// InferOwner parses an owner string whose type is not given: hex encoding a
+// public key or an address, with or without a 0x prefix and in any case, is
+// taken as such, then hex matching the payload length of another registered
+// type, and anything else as an account name.
+func InferOwner(s string) (Owner, error) {
+	s = strings.TrimSpace(s)
+	if normalized, err := NormalizePubKeyHex(s); err == nil {
+		return HexToPubKey(normalized), nil
+	}
+	if b, err := decodeOwnerHex(s, AddressLength); err == nil {
+		return BytesToAddress(b), nil
+	}
+	for _, info := range RegisteredAuthorTypes() {
+		if info.Type <= AddressType || len(info.Lengths) == 0 {
+			continue
+		}
+		if owner, err := GenerateOwnerE(s, info.Type); err == nil {
+			return owner, nil
+		}
+	}
+	return GenerateOwnerE(s, AccountNameType)
+}
+
+// OwnerStringAmbiguity returns every registered author type s could validly
+// represent, in type order. More than one result means s is ambiguous without
+// an explicit type. Names are checked with IsValidName rather than
+// GenerateOwnerE, which accepts any non-empty string as a name.
+func OwnerStringAmbiguity(s string) []AuthorType {
+	var matches []AuthorType
+	for _, info := range RegisteredAuthorTypes() {
+		var valid bool
+		if info.Type == AccountNameType {
+			valid = IsValidName(s)
+		} else {
+			_, err := GenerateOwnerE(s, info.Type)
+			valid = err == nil
+		}
+		if valid {
+			matches = append(matches, info.Type)
+		}
+	}
+	return matches
+}
+
+// UnmarshalAuthors decodes a JSON array of authors of the form
+// {"owner": ..., "weight": ..., "type": ...}. The type is optional; without it
+// the owner is inferred with InferOwner, so hex owners may mix prefixed and
+// unprefixed, upper and lower case spellings. Errors name the offending index.
+func UnmarshalAuthors(data []byte) ([]*Author, error) {
+	var raw []rawAuthorJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	authors := make([]*Author, len(raw))
+	for i, r := range raw {
+		a, err := r.author()
+		if err != nil {
+			return nil, fmt.Errorf("author %d: %w", i, err)
+		}
+		authors[i] = a
+	}
+	return authors, nil
+}
+
+// rawAuthorJSON is the JSON form of an author with an optional owner type.
+type rawAuthorJSON struct {
+	Type   *string `json:"type"`
+	Owner  string  `json:"owner"`
+	Weight uint64  `json:"weight"`
+}
+
+// author parses r as its given type, or infers the type with InferOwner.
+func (r *rawAuthorJSON) author() (*Author, error) {
+	if r.Type == nil {
+		owner, err := InferOwner(r.Owner)
+		if err != nil {
+			return nil, err
+		}
+		return NewAuthor(owner, r.Weight), nil
+	}
+	at, err := ParseAuthorType(*r.Type)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := GenerateOwnerE(strings.TrimSpace(r.Owner), at)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthor(owner, r.Weight), nil
+}
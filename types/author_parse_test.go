@@ -0,0 +1,210 @@
+package types
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testPubKeyHex = "0x04" + strings.Repeat("ab", PubKeyLength-1)
+
+func TestAuthorFromValues(t *testing.T) {
+	tests := []struct {
+		query  string
+		owner  Owner
+		weight uint64
+	}{
+		{"type=account&owner=alice&weight=3", Name("alice"), 3},
+		{"type=pubkey&owner=" + testPubKeyHex + "&weight=2", HexToPubKey(testPubKeyHex), 2},
+		{"type=address&owner=0x00000000000000000000000000000000000000ff", BytesToAddress([]byte{0xff}), 1},
+	}
+	for _, test := range tests {
+		v, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, err := AuthorFromValues(v)
+		if err != nil {
+			t.Fatalf("%s: %v", test.query, err)
+		}
+		if a.Owner != test.owner || a.Weight != test.weight {
+			t.Errorf("%s: got %v/%d, want %v/%d", test.query, a.Owner, a.Weight, test.owner, test.weight)
+		}
+	}
+}
+
+func TestAuthorFromValuesErrors(t *testing.T) {
+	v := url.Values{"type": {"pubkey"}}
+	if _, err := AuthorFromValues(v); !errors.Is(err, ErrInvalidOwner) {
+		t.Errorf("missing owner: got %v, want %v", err, ErrInvalidOwner)
+	}
+	v = url.Values{"type": {"key"}, "owner": {"alice"}}
+	if _, err := AuthorFromValues(v); !errors.Is(err, ErrInvalidAuthorType) {
+		t.Errorf("bad type: got %v, want %v", err, ErrInvalidAuthorType)
+	}
+	v = url.Values{"type": {"address"}, "owner": {"0x1234"}}
+	if _, err := AuthorFromValues(v); !errors.Is(err, ErrInvalidOwner) {
+		t.Errorf("short address: got %v, want %v", err, ErrInvalidOwner)
+	}
+}
+
+func TestAuthorsFromEnv(t *testing.T) {
+	t.Setenv("TEST_AUTHOR_0", "pubkey:"+testPubKeyHex+"@2")
+	t.Setenv("TEST_AUTHOR_1", "account:alice")
+	t.Setenv("TEST_AUTHOR_2", "address:0x00000000000000000000000000000000000000ff@3")
+	t.Setenv("TEST_AUTHOR_4", "account:skipped")
+
+	authors, err := AuthorsFromEnv("TEST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Author{
+		NewAuthor(HexToPubKey(testPubKeyHex), 2),
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(BytesToAddress([]byte{0xff}), 3),
+	}
+	if len(authors) != len(want) {
+		t.Fatalf("got %d authors, want %d", len(authors), len(want))
+	}
+	for i := range want {
+		if !authors[i].Equal(want[i]) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, authors[i].Owner, authors[i].Weight, want[i].Owner, want[i].Weight)
+		}
+	}
+
+	t.Setenv("TEST_AUTHOR_1", "account:alice@heavy")
+	if _, err := AuthorsFromEnv("TEST"); err == nil || !strings.HasPrefix(err.Error(), "TEST_AUTHOR_1: ") {
+		t.Errorf("got error %v, want it to name TEST_AUTHOR_1", err)
+	}
+}
+
+func TestAuthorLinkToken(t *testing.T) {
+	for _, a := range GenerateTestAuthors(3, 1) {
+		tok, err := a.LinkToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.ContainsAny(tok, "+/=") {
+			t.Errorf("token %q is not URL-safe", tok)
+		}
+		got, err := AuthorFromLinkToken(tok)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(a) {
+			t.Errorf("got %v/%d, want %v/%d", got.Owner, got.Weight, a.Owner, a.Weight)
+		}
+	}
+
+	for _, tok := range []string{"not base64!", "AAAA", ""} {
+		if _, err := AuthorFromLinkToken(tok); !errors.Is(err, ErrInvalidLinkToken) {
+			t.Errorf("%q: got %v, want %v", tok, err, ErrInvalidLinkToken)
+		}
+	}
+}
+
+func TestNormalizePubKeyHex(t *testing.T) {
+	bare := strings.Repeat("ab", PubKeyLength)
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{bare, bare, true},
+		{"0x" + bare, bare, true},
+		{" 0X" + strings.ToUpper(bare) + "\n", bare, true},
+		{"0x" + bare[1:], "", false},
+		{"0x" + bare[2:], "", false},
+		{"0x0x" + bare, "", false},
+		{"0x" + bare[2:] + "zz", "", false},
+	}
+	for _, test := range tests {
+		got, err := NormalizePubKeyHex(test.in)
+		if test.ok != (err == nil) || got != test.want {
+			t.Errorf("%q: got %q, %v", test.in, got, err)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidOwner) {
+			t.Errorf("%q: got %v, want %v", test.in, err, ErrInvalidOwner)
+		}
+	}
+
+	owner, err := ParseOwnerURI("pubkey:0X" + strings.ToUpper(bare))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner != HexToPubKey(bare) {
+		t.Errorf("got %v, want %v", owner, HexToPubKey(bare))
+	}
+}
+
+func TestUnmarshalAuthorsMixedFormats(t *testing.T) {
+	bare := strings.Repeat("ab", PubKeyLength)
+	address := "00000000000000000000000000000000000000ff"
+	data := `[
+		{"owner": "0x` + bare + `", "weight": 1},
+		{"owner": "` + strings.ToUpper(bare) + `", "weight": 2},
+		{"owner": "0X` + address + `", "weight": 3},
+		{"owner": "` + address + `", "weight": 4, "type": "address"},
+		{"owner": "alice", "weight": 5},
+		{"owner": "0xab", "weight": 6, "type": "account"}
+	]`
+	authors, err := UnmarshalAuthors([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Author{
+		NewAuthor(HexToPubKey(bare), 1),
+		NewAuthor(HexToPubKey(bare), 2),
+		NewAuthor(BytesToAddress([]byte{0xff}), 3),
+		NewAuthor(BytesToAddress([]byte{0xff}), 4),
+		NewAuthor(Name("alice"), 5),
+		NewAuthor(Name("0xab"), 6),
+	}
+	if len(authors) != len(want) {
+		t.Fatalf("got %d authors, want %d", len(authors), len(want))
+	}
+	for i := range want {
+		if !authors[i].Equal(want[i]) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, authors[i].Owner, authors[i].Weight, want[i].Owner, want[i].Weight)
+		}
+	}
+
+	bad := `[{"owner": "alice", "weight": 1}, {"owner": "0x12", "weight": 1, "type": "pubkey"}]`
+	if _, err := UnmarshalAuthors([]byte(bad)); err == nil || !strings.HasPrefix(err.Error(), "author 1: ") {
+		t.Errorf("got error %v, want it to name author 1", err)
+	}
+}
+
+func TestOwnerStringAmbiguity(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []AuthorType
+	}{
+		{"", nil},
+		{"alice", []AuthorType{AccountNameType}},
+		{"alice.bob", []AuthorType{AccountNameType}},
+		{"Alice", nil},
+		{"0x" + strings.Repeat("ab", 10), nil},
+		{"0x" + strings.Repeat("ab", AddressLength), []AuthorType{AddressType}},
+		{strings.Repeat("AB", AddressLength), []AuthorType{AddressType}},
+		{testPubKeyHex, []AuthorType{PubKeyType}},
+		{"0x" + strings.Repeat("ab", 48), []AuthorType{ExtendedPubKeyType}},
+	}
+	for _, test := range tests {
+		if got := OwnerStringAmbiguity(test.s); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %v, want %v", test.s, got, test.want)
+		}
+	}
+
+	info, _ := DefaultAuthorTypeRegistry.Lookup(ExtendedPubKeyType)
+	defer DefaultAuthorTypeRegistry.SetLengths(ExtendedPubKeyType, info.Lengths...)
+	if err := DefaultAuthorTypeRegistry.SetLengths(ExtendedPubKeyType, 4); err != nil {
+		t.Fatal(err)
+	}
+	want := []AuthorType{AccountNameType, ExtendedPubKeyType}
+	if got := OwnerStringAmbiguity("abababab"); !reflect.DeepEqual(got, want) {
+		t.Errorf("short hex name: got %v, want %v", got, want)
+	}
+}
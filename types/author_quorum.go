@@ -0,0 +1,207 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// ThresholdQuorum describes how a single threshold can be met.
+type ThresholdQuorum struct {
+	Threshold uint64    `json:"threshold"`
+	Signers   []*Author `json:"signers"`
+	Reachable bool      `json:"reachable"`
+	Unchanged bool      `json:"unchanged,omitempty"` // the action keeps the account's stored threshold
+}
+
+// QuorumInfo reports the quorum requirements of both account thresholds.
+type QuorumInfo struct {
+	Threshold             ThresholdQuorum `json:"threshold"`
+	UpdateAuthorThreshold ThresholdQuorum `json:"updateAuthorThreshold"`
+}
+
+// QuorumOptions configures VerifyQuorum.
+type QuorumOptions struct {
+	// CollapseEquivalentOwners canonicalizes pubkey owners to the address
+	// derived from them, so that a pubkey author and the address author of the
+	// same key are treated as one owner. A pubkey that is not a valid curve
+	// point has no address form and is left as is.
+	CollapseEquivalentOwners bool
+}
+
+func (opts QuorumOptions) ownerKey(owner Owner) string {
+	if pubKey, ok := owner.(PubKey); ok && opts.CollapseEquivalentOwners {
+		if address, err := PubKeyToAddress(pubKey); err == nil {
+			return OwnerKey(address)
+		}
+	}
+	return OwnerKey(owner)
+}
+
+// SignerWeight returns the weight signers carry in authors. Each owner counts
+// once however many signers match it; authors that share an owner, including
+// a pubkey and address author collapsed by opts, contribute only the largest
+// of their weights.
+func SignerWeight(authors []*Author, signers []Owner, opts QuorumOptions) (uint64, error) {
+	signed := make(map[string]bool)
+	for _, s := range signers {
+		signed[opts.ownerKey(s)] = true
+	}
+	weights := make(map[string]uint64)
+	for _, a := range authors {
+		key := opts.ownerKey(a.Owner)
+		if signed[key] && a.Weight > weights[key] {
+			weights[key] = a.Weight
+		}
+	}
+	var total uint64
+	for _, w := range weights {
+		if total > math.MaxUint64-w {
+			return 0, ErrWeightOverflow
+		}
+		total += w
+	}
+	return total, nil
+}
+
+// VerifyQuorum reports whether signers carry at least threshold weight in
+// authors, counted as described by SignerWeight.
+func VerifyQuorum(authors []*Author, signers []Owner, threshold uint64, opts QuorumOptions) (bool, error) {
+	weight, err := SignerWeight(authors, signers, opts)
+	if err != nil {
+		return false, err
+	}
+	return weight >= threshold, nil
+}
+
+// MinimalSignerSet returns the smallest set of authors whose combined weight
+// meets threshold, taking the heaviest authors first. Ties are broken by
+// OwnerKey so the result is deterministic. It returns false if the whole set
+// cannot meet threshold.
+func MinimalSignerSet(authors []*Author, threshold uint64) ([]*Author, bool) {
+	sorted := make([]*Author, len(authors))
+	copy(sorted, authors)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Weight != sorted[j].Weight {
+			return sorted[i].Weight > sorted[j].Weight
+		}
+		return OwnerKey(sorted[i].Owner) < OwnerKey(sorted[j].Owner)
+	})
+	signers := make([]*Author, 0)
+	var weight uint64
+	for _, a := range sorted {
+		if weight >= threshold {
+			break
+		}
+		signers = append(signers, a)
+		if weight > math.MaxUint64-a.Weight {
+			// the sum no longer fits, so it certainly meets threshold
+			return signers, true
+		}
+		weight += a.Weight
+	}
+	if weight < threshold {
+		return nil, false
+	}
+	return signers, true
+}
+
+// MaxSignersNeeded returns the worst-case number of signers needed to meet
+// threshold: the count reached by summing the lightest authors first. It is
+// the upper bound to MinimalSignerSet's best case, and returns false if the
+// whole set cannot meet threshold.
+func MaxSignersNeeded(authors []*Author, threshold uint64) (int, bool) {
+	weights := make([]uint64, len(authors))
+	for i, a := range authors {
+		weights[i] = a.Weight
+	}
+	sort.Slice(weights, func(i, j int) bool { return weights[i] < weights[j] })
+	var weight uint64
+	for i, w := range weights {
+		if weight >= threshold {
+			return i, true
+		}
+		if weight > math.MaxUint64-w {
+			return i + 1, true
+		}
+		weight += w
+	}
+	if weight < threshold {
+		return 0, false
+	}
+	return len(weights), true
+}
+
+// QuorumSummary reports, for both thresholds in effect after aa, the
+// minimal signer set of current that meets it and whether it is reachable at
+// all. A threshold aa leaves unset keeps the account's stored value from
+// opts and is marked Unchanged; if that is unknown too, the threshold is
+// reported as zero and unreachable rather than as needing no signers.
+func (aa *AccountAuthorAction) QuorumSummary(current []*Author, opts ValidateOptions) QuorumInfo {
+	summarize := func(proposed, stored uint64) ThresholdQuorum {
+		q := ThresholdQuorum{Threshold: proposed}
+		if proposed == 0 {
+			q.Threshold, q.Unchanged = stored, true
+		}
+		if q.Threshold == 0 {
+			return q
+		}
+		q.Signers, q.Reachable = MinimalSignerSet(current, q.Threshold)
+		return q
+	}
+	return QuorumInfo{
+		Threshold:             summarize(aa.Threshold, opts.Threshold),
+		UpdateAuthorThreshold: summarize(aa.UpdateAuthorThreshold, opts.UpdateAuthorThreshold),
+	}
+}
+
+// AuthorizeUpdate reports whether signers may submit aa: they must carry
+// enough weight in current to meet the account's stored update author
+// threshold, opts.UpdateAuthorThreshold, and aa must pass
+// ValidateAuthorization under opts. The thresholds aa proposes only take
+// effect once it is accepted, so they never lower the bar for aa itself.
+func AuthorizeUpdate(current []*Author, signers []Owner, aa *AccountAuthorAction, opts ValidateOptions) (bool, error) {
+	if opts.UpdateAuthorThreshold == 0 {
+		return false, fmt.Errorf("%w: account update author threshold unknown", ErrZeroThreshold)
+	}
+	ok, err := VerifyQuorum(current, signers, opts.UpdateAuthorThreshold, QuorumOptions{})
+	if err != nil || !ok {
+		return false, err
+	}
+	if _, err := ValidateAuthorization(current, aa, opts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CriticalAuthors returns the authors without whom the rest of the set could
+// no longer reach threshold, i.e. the single points of failure. If the whole
+// set cannot reach threshold, or its weight overflows, no author is singled
+// out and nil is returned.
+func CriticalAuthors(authors []*Author, threshold uint64) []*Author {
+	total, err := TotalWeight(authors)
+	if err != nil || total < threshold {
+		return nil
+	}
+	critical := make([]*Author, 0)
+	for _, a := range authors {
+		if total-a.Weight < threshold {
+			critical = append(critical, a)
+		}
+	}
+	return critical
+}
+
+// SatisfiableThresholds reports, for each of thresholds, whether signers carry
+// enough weight in authors to meet it.
+func SatisfiableThresholds(authors []*Author, signers []Owner, thresholds ...uint64) ([]bool, error) {
+	weight, err := SignerWeight(authors, signers, QuorumOptions{})
+	if err != nil {
+		return nil, err
+	}
+	satisfiable := make([]bool, len(thresholds))
+	for i, threshold := range thresholds {
+		satisfiable[i] = weight >= threshold
+	}
+	return satisfiable, nil
+}
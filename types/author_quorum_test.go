@@ -0,0 +1,218 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+func TestQuorumSummary(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 3),
+		NewAuthor(Name("bob"), 2),
+		NewAuthor(Name("carol"), 1),
+	}
+	aa := &AccountAuthorAction{Threshold: 2, UpdateAuthorThreshold: 5}
+	info := aa.QuorumSummary(current, ValidateOptions{})
+
+	if !info.Threshold.Reachable || len(info.Threshold.Signers) != 1 || info.Threshold.Signers[0].Owner != Name("alice") {
+		t.Errorf("threshold: got %+v, want alice alone", info.Threshold)
+	}
+	if !info.UpdateAuthorThreshold.Reachable || len(info.UpdateAuthorThreshold.Signers) != 2 {
+		t.Errorf("update threshold: got %+v, want alice and bob", info.UpdateAuthorThreshold)
+	}
+
+	aa.UpdateAuthorThreshold = 7
+	info = aa.QuorumSummary(current, ValidateOptions{})
+	if info.UpdateAuthorThreshold.Reachable || info.UpdateAuthorThreshold.Signers != nil {
+		t.Errorf("update threshold: got %+v, want unreachable", info.UpdateAuthorThreshold)
+	}
+	if !info.Threshold.Reachable {
+		t.Errorf("threshold: got unreachable, want reachable")
+	}
+
+	unset := &AccountAuthorAction{Threshold: 2}
+	info = unset.QuorumSummary(current, ValidateOptions{UpdateAuthorThreshold: 4})
+	if q := info.UpdateAuthorThreshold; !q.Unchanged || q.Threshold != 4 || !q.Reachable || len(q.Signers) != 2 {
+		t.Errorf("stored update threshold: got %+v, want unchanged 4 met by alice and bob", q)
+	}
+	if info.Threshold.Unchanged {
+		t.Errorf("threshold: got unchanged, want the proposed value")
+	}
+	info = unset.QuorumSummary(current, ValidateOptions{})
+	if q := info.UpdateAuthorThreshold; q.Reachable || q.Threshold != 0 || q.Signers != nil {
+		t.Errorf("unknown update threshold: got %+v, want unreachable with no signers", q)
+	}
+}
+
+func TestVerifyQuorumCollapseEquivalentOwners(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := BytesToPubKey(crypto.FromECDSAPub(&key.PublicKey))
+	address, err := PubKeyToAddress(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authors := []*Author{
+		NewAuthor(pubKey, 2),
+		NewAuthor(address, 3),
+		NewAuthor(Name("alice"), 1),
+	}
+	signers := []Owner{pubKey, address}
+
+	weight, err := SignerWeight(authors, signers, QuorumOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weight != 5 {
+		t.Errorf("separate owners: got weight %d, want 5", weight)
+	}
+
+	collapse := QuorumOptions{CollapseEquivalentOwners: true}
+	for _, signers := range [][]Owner{{pubKey}, {address}, {pubKey, address}} {
+		weight, err := SignerWeight(authors, signers, collapse)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if weight != 3 {
+			t.Errorf("collapsed owners signed by %v: got weight %d, want 3", signers, weight)
+		}
+	}
+	if ok, _ := VerifyQuorum(authors, []Owner{pubKey}, 4, collapse); ok {
+		t.Errorf("collapsed owners met threshold 4, want weight counted once")
+	}
+	if ok, _ := VerifyQuorum(authors, []Owner{pubKey, Name("alice")}, 4, collapse); !ok {
+		t.Errorf("collapsed owners and alice did not meet threshold 4")
+	}
+}
+
+func TestAuthorizeUpdate(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	opts := ValidateOptions{Threshold: 1, UpdateAuthorThreshold: 3}
+	aa := &AccountAuthorAction{Threshold: 2}
+
+	ok, err := AuthorizeUpdate(current, []Owner{Name("alice"), Name("carol")}, aa, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("alice and carol not authorized, want authorized")
+	}
+	ok, err = AuthorizeUpdate(current, []Owner{Name("bob"), Name("carol"), Name("dave")}, aa, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("bob, carol and dave authorized, want insufficient weight")
+	}
+	if _, err := AuthorizeUpdate(current, []Owner{Name("alice")}, aa, ValidateOptions{}); !errors.Is(err, ErrZeroThreshold) {
+		t.Errorf("unknown stored threshold: got %v, want %v", err, ErrZeroThreshold)
+	}
+	invalid := &AccountAuthorAction{Threshold: 5}
+	if _, err := AuthorizeUpdate(current, []Owner{Name("alice"), Name("carol")}, invalid, opts); !errors.Is(err, ErrThresholdUnreachable) {
+		t.Errorf("invalid action: got %v, want %v", err, ErrThresholdUnreachable)
+	}
+}
+
+func TestAuthorizeUpdateIgnoresProposedThreshold(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	opts := ValidateOptions{Threshold: 1, UpdateAuthorThreshold: 2}
+	aa := &AccountAuthorAction{
+		UpdateAuthorThreshold: 1,
+		AuthorActions:         []*AuthorAction{{ActionType: DeleteAuthor, Author: NewAuthor(Name("bob"), 0)}},
+	}
+	ok, err := AuthorizeUpdate(current, []Owner{Name("alice")}, aa, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Errorf("alice alone authorized by lowering the update threshold, want insufficient weight")
+	}
+	if ok, err := AuthorizeUpdate(current, []Owner{Name("alice"), Name("carol")}, aa, opts); err != nil || !ok {
+		t.Errorf("alice and carol: got %v, %v, want authorized", ok, err)
+	}
+}
+
+func TestCriticalAuthors(t *testing.T) {
+	redundant := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	if critical := CriticalAuthors(redundant, 2); len(critical) != 0 {
+		t.Errorf("2 of 3: got %d critical authors, want none", len(critical))
+	}
+
+	skewed := []*Author{
+		NewAuthor(Name("alice"), 3),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	critical := CriticalAuthors(skewed, 3)
+	if len(critical) != 1 || critical[0].Owner != Name("alice") {
+		t.Errorf("got %d critical authors, want alice alone", len(critical))
+	}
+	if critical := CriticalAuthors(skewed, 6); critical != nil {
+		t.Errorf("unreachable threshold: got %d critical authors, want nil", len(critical))
+	}
+}
+
+func TestSatisfiableThresholds(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 2),
+	}
+	got, err := SatisfiableThresholds(authors, []Owner{Name("alice"), Name("bob")}, 2, 3, 4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []bool{true, true, false, false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMaxSignersNeeded(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 5),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+		NewAuthor(Name("dave"), 2),
+	}
+	tests := []struct {
+		threshold uint64
+		best      int
+		worst     int
+	}{
+		{0, 0, 0},
+		{4, 1, 3},
+		{5, 1, 4},
+		{9, 4, 4},
+	}
+	for _, test := range tests {
+		minimal, ok := MinimalSignerSet(authors, test.threshold)
+		if !ok || len(minimal) != test.best {
+			t.Errorf("threshold %d: best case %d signers, want %d", test.threshold, len(minimal), test.best)
+		}
+		worst, ok := MaxSignersNeeded(authors, test.threshold)
+		if !ok || worst != test.worst {
+			t.Errorf("threshold %d: worst case %d signers, want %d", test.threshold, worst, test.worst)
+		}
+	}
+	if _, ok := MaxSignersNeeded(authors, 10); ok {
+		t.Errorf("threshold 10 reachable, want unreachable")
+	}
+}
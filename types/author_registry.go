@@ -0,0 +1,179 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ExtendedPubKeyType AuthorType = AddressType + 1
+
+var (
+	ErrAuthorTypeRegistered = errors.New("author type already registered")
+	ErrInvalidOwnerLength   = errors.New("invalid author owner length")
+)
+
+// TypedOwner is implemented by owners of author types beyond the built-in
+// name, pubkey and address, so that they can be encoded through the
+// AuthorTypeRegistry.
+type TypedOwner interface {
+	Owner
+	AuthorType() AuthorType
+	Bytes() []byte
+}
+
+// AuthorTypeInfo describes a registered author type.
+type AuthorTypeInfo struct {
+	Type        AuthorType `json:"type"`
+	Name        string     `json:"name"`
+	Lengths     []int      `json:"lengths,omitempty"` // accepted payload lengths, empty if unrestricted
+	Description string     `json:"description"`
+
+	// Decode builds an owner from its payload. It is only used for types
+	// beyond the built-in three, whose decoding is fixed.
+	Decode func([]byte) (Owner, error) `json:"-"`
+}
+
+func (info AuthorTypeInfo) validLength(n int) bool {
+	if len(info.Lengths) == 0 {
+		return true
+	}
+	for _, l := range info.Lengths {
+		if n == l {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorTypeRegistry holds the known author types.
+type AuthorTypeRegistry struct {
+	mu    sync.RWMutex
+	infos map[AuthorType]AuthorTypeInfo
+}
+
+// Register adds a new author type to the registry.
+func (r *AuthorTypeRegistry) Register(info AuthorTypeInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.infos[info.Type]; ok {
+		return fmt.Errorf("%w: %d", ErrAuthorTypeRegistered, info.Type)
+	}
+	r.infos[info.Type] = info
+	return nil
+}
+
+// Lookup returns the registered info for at.
+func (r *AuthorTypeRegistry) Lookup(at AuthorType) (AuthorTypeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.infos[at]
+	return info, ok
+}
+
+// Name returns the registered name of at.
+func (r *AuthorTypeRegistry) Name(at AuthorType) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.infos[at]
+	return info.Name, ok
+}
+
+// TypeByName returns the registered author type named name, matched
+// case-insensitively.
+func (r *AuthorTypeRegistry) TypeByName(name string) (AuthorType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for at, info := range r.infos {
+		if strings.EqualFold(info.Name, name) {
+			return at, true
+		}
+	}
+	return 0, false
+}
+
+// SetLengths changes the payload lengths accepted for at.
+func (r *AuthorTypeRegistry) SetLengths(at AuthorType, lengths ...int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	info, ok := r.infos[at]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrInvalidAuthorType, at)
+	}
+	info.Lengths = lengths
+	r.infos[at] = info
+	return nil
+}
+
+// Types returns the registered author types ordered by value.
+func (r *AuthorTypeRegistry) Types() []AuthorTypeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	infos := make([]AuthorTypeInfo, 0, len(r.infos))
+	for _, info := range r.infos {
+		info.Lengths = append([]int(nil), info.Lengths...)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Type < infos[j].Type })
+	return infos
+}
+
+// authorTypeName returns the name of at in DefaultAuthorTypeRegistry, or its
+// number if it is not registered.
+func authorTypeName(at AuthorType) string {
+	if name, ok := DefaultAuthorTypeRegistry.Name(at); ok {
+		return name
+	}
+	return strconv.Itoa(int(at))
+}
+
+// RegisteredAuthorTypes returns the author types of DefaultAuthorTypeRegistry,
+// for generating documentation and client-side validation.
+func RegisteredAuthorTypes() []AuthorTypeInfo {
+	return DefaultAuthorTypeRegistry.Types()
+}
+
+// DefaultAuthorTypeRegistry holds the built-in author types and
+// ExtendedPubKeyType.
+var DefaultAuthorTypeRegistry = &AuthorTypeRegistry{infos: make(map[AuthorType]AuthorTypeInfo)}
+
+func init() {
+	for _, info := range []AuthorTypeInfo{
+		{Type: AccountNameType, Name: AuthorTypeToString[AccountNameType], Description: "account name"},
+		{Type: PubKeyType, Name: AuthorTypeToString[PubKeyType], Lengths: []int{PubKeyLength}, Description: "uncompressed secp256k1 public key"},
+		{Type: AddressType, Name: AuthorTypeToString[AddressType], Lengths: []int{AddressLength}, Description: "address derived from a public key"},
+		{Type: ExtendedPubKeyType, Name: "extendedPubKey", Lengths: []int{48, 96}, Description: "public key of a non-secp256k1 signature scheme, e.g. BLS",
+			Decode: func(b []byte) (Owner, error) { return NewExtendedPubKey(b) }},
+	} {
+		if err := DefaultAuthorTypeRegistry.Register(info); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExtendedPubKey is a public key of a signature scheme other than secp256k1,
+// whose accepted lengths are those registered for ExtendedPubKeyType.
+type ExtendedPubKey []byte
+
+// NewExtendedPubKey returns a copy of b as an ExtendedPubKey, checking its
+// length against DefaultAuthorTypeRegistry.
+func NewExtendedPubKey(b []byte) (ExtendedPubKey, error) {
+	info, _ := DefaultAuthorTypeRegistry.Lookup(ExtendedPubKeyType)
+	if !info.validLength(len(b)) {
+		return nil, fmt.Errorf("%w: %d byte extended pubkey, want one of %v", ErrInvalidOwnerLength, len(b), info.Lengths)
+	}
+	return ExtendedPubKey(CopyBytes(b)), nil
+}
+
+// AuthorType implements TypedOwner.
+func (p ExtendedPubKey) AuthorType() AuthorType { return ExtendedPubKeyType }
+
+// Bytes implements TypedOwner.
+func (p ExtendedPubKey) Bytes() []byte { return p }
+
+// String implements fmt.Stringer.
+func (p ExtendedPubKey) String() string { return "0x" + hex.EncodeToString(p) }
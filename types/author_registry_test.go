@@ -0,0 +1,175 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/browser/rlp"
+)
+
+func TestExtendedPubKeyRoundTrip(t *testing.T) {
+	key, err := NewExtendedPubKey(bytes.Repeat([]byte{0xb1}, 48))
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := NewAuthor(key, 2)
+	enc, err := rlp.EncodeToBytes(author)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sa := new(StorageAuthor)
+	if err := rlp.DecodeBytes(enc, sa); err != nil {
+		t.Fatal(err)
+	}
+	if sa.Type != ExtendedPubKeyType {
+		t.Errorf("got storage type %d, want %d", sa.Type, ExtendedPubKeyType)
+	}
+	dec := new(Author)
+	if err := rlp.DecodeBytes(enc, dec); err != nil {
+		t.Fatal(err)
+	}
+	if !dec.Equal(author) {
+		t.Errorf("got %v/%d, want %v/%d", dec.Owner, dec.Weight, author.Owner, author.Weight)
+	}
+	if _, ok := dec.Owner.(ExtendedPubKey); !ok {
+		t.Errorf("decoded owner is %T, want ExtendedPubKey", dec.Owner)
+	}
+
+	owner, err := GenerateOwnerE(key.String(), ExtendedPubKeyType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(owner.(ExtendedPubKey), key) {
+		t.Errorf("parsed %v, want %v", owner, key)
+	}
+}
+
+func TestExtendedPubKeyJSON(t *testing.T) {
+	key, err := NewExtendedPubKey(bytes.Repeat([]byte{0xb1}, 48))
+	if err != nil {
+		t.Fatal(err)
+	}
+	author := NewAuthor(key, 2)
+	data, err := json.Marshal(author)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"extendedPubKey","owner":"` + key.String() + `","weight":2}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+	dec := new(Author)
+	if err := json.Unmarshal(data, dec); err != nil {
+		t.Fatal(err)
+	}
+	if !dec.Equal(author) {
+		t.Errorf("typed JSON decoded as %T %v", dec.Owner, dec.Owner)
+	}
+
+	untyped := []byte(`{"owner":"` + key.String() + `","weight":2}`)
+	if err := json.Unmarshal(untyped, dec); err != nil {
+		t.Fatal(err)
+	}
+	if !dec.Equal(author) {
+		t.Errorf("untyped JSON decoded as %T %v", dec.Owner, dec.Owner)
+	}
+
+	att, err := AttestAuthorSet([]*Author{author, NewAuthor(Name("alice"), 1)}, 2, func([]byte) ([]byte, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err = json.Marshal(att); err != nil {
+		t.Fatal(err)
+	}
+	decoded := new(AuthorAttestation)
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if hash, err := attestationHash(decoded.Authors, decoded.Threshold); err != nil || hash != att.Hash {
+		t.Errorf("attestation hash changed after a JSON round trip: %v", err)
+	}
+}
+
+func TestExtendedPubKeyLengths(t *testing.T) {
+	if _, err := NewExtendedPubKey(make([]byte, PubKeyLength)); !errors.Is(err, ErrInvalidOwnerLength) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOwnerLength)
+	}
+	enc, err := rlp.EncodeToBytes(&StorageAuthor{Type: ExtendedPubKeyType, DataRaw: []byte{0x82, 1, 2}, Weight: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(enc, new(Author)); !errors.Is(err, ErrInvalidOwnerLength) {
+		t.Errorf("decode short key: got %v, want %v", err, ErrInvalidOwnerLength)
+	}
+
+	info, _ := DefaultAuthorTypeRegistry.Lookup(ExtendedPubKeyType)
+	defer DefaultAuthorTypeRegistry.SetLengths(ExtendedPubKeyType, info.Lengths...)
+	if err := DefaultAuthorTypeRegistry.SetLengths(ExtendedPubKeyType, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(enc, new(Author)); err != nil {
+		t.Errorf("decode after accepting 2 byte keys: %v", err)
+	}
+}
+
+func TestRegisteredAuthorTypes(t *testing.T) {
+	infos := RegisteredAuthorTypes()
+	want := []struct {
+		at      AuthorType
+		name    string
+		lengths []int
+	}{
+		{AccountNameType, "account", nil},
+		{PubKeyType, "pubKey", []int{PubKeyLength}},
+		{AddressType, "address", []int{AddressLength}},
+	}
+	if len(infos) < len(want) {
+		t.Fatalf("got %d types, want at least %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		info := infos[i]
+		if info.Type != w.at || info.Name != w.name || !reflect.DeepEqual(info.Lengths, w.lengths) || info.Description == "" {
+			t.Errorf("type %d: got %+v", w.at, info)
+		}
+	}
+
+	infos[1].Lengths[0] = 1
+	if info, _ := DefaultAuthorTypeRegistry.Lookup(PubKeyType); info.Lengths[0] != PubKeyLength {
+		t.Errorf("modifying the returned lengths changed the registry")
+	}
+}
+
+func TestRegisterConcurrentLookups(t *testing.T) {
+	r := &AuthorTypeRegistry{infos: make(map[AuthorType]AuthorTypeInfo)}
+	builtins := len(AuthorTypeToString)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		at := AuthorType(100 + i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := r.Register(AuthorTypeInfo{Type: at, Name: "test" + string(rune('a'+i))}); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			r.TypeByName("testa")
+			ParseAuthorType("pubkey")
+		}()
+	}
+	wg.Wait()
+
+	if at, ok := r.TypeByName("TESTC"); !ok || at != 102 {
+		t.Errorf("TypeByName: got %d, %v, want 102", at, ok)
+	}
+	if name, ok := r.Name(103); !ok || name != "testd" {
+		t.Errorf("Name: got %q, %v, want testd", name, ok)
+	}
+	if len(AuthorTypeToString) != builtins {
+		t.Errorf("Register modified AuthorTypeToString")
+	}
+}
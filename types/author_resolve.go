@@ -0,0 +1,129 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrResolveDepth = errors.New("author resolution exceeds max depth")
+	ErrNoPrimaryKey = errors.New("account has no single primary key")
+)
+
+// OwnerResolver looks up the authors controlling an account name.
+type OwnerResolver interface {
+	ResolveAuthors(name Name) ([]*Author, error)
+}
+
+// resolveKeys collects the OwnerKeys of the pubkey and address owners that
+// ultimately control owner, following name authors through r. Names already
+// visited are skipped so that cyclic name chains terminate.
+func resolveKeys(owner Owner, r OwnerResolver, depth, maxDepth int, visited map[Name]bool, keys map[string]bool) error {
+	name, ok := owner.(Name)
+	if !ok {
+		keys[OwnerKey(owner)] = true
+		return nil
+	}
+	if visited[name] {
+		return nil
+	}
+	if depth >= maxDepth {
+		return fmt.Errorf("%w: %s", ErrResolveDepth, name)
+	}
+	visited[name] = true
+	authors, err := r.ResolveAuthors(name)
+	if err != nil {
+		return err
+	}
+	for _, a := range authors {
+		if err := resolveKeys(a.Owner, r, depth+1, maxDepth, visited, keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DetectAliasedAuthority groups authors whose resolution reaches a common
+// pubkey or address, i.e. authors whose weight is effectively controlled by
+// the same key. Only groups of two or more authors are returned, in input
+// order. Name chains are followed at most maxDepth levels deep.
+func DetectAliasedAuthority(authors []*Author, r OwnerResolver, maxDepth int) ([][]*Author, error) {
+	parent := make([]int, len(authors))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	owners := make(map[string]int)
+	for i, a := range authors {
+		keys := make(map[string]bool)
+		if err := resolveKeys(a.Owner, r, 0, maxDepth, make(map[Name]bool), keys); err != nil {
+			return nil, err
+		}
+		for key := range keys {
+			if j, ok := owners[key]; ok {
+				if ri, rj := find(i), find(j); ri != rj {
+					if ri < rj {
+						parent[rj] = ri
+					} else {
+						parent[ri] = rj
+					}
+				}
+				continue
+			}
+			owners[key] = i
+		}
+	}
+
+	groups := make(map[int][]*Author)
+	var roots []int
+	for i, a := range authors {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], a)
+	}
+	aliased := make([][]*Author, 0)
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			aliased = append(aliased, groups[root])
+		}
+	}
+	return aliased, nil
+}
+
+// AddressAuthorForName builds an address author tracking the primary key of
+// name, i.e. the single pubkey author among the authors r resolves for it.
+// It returns ErrNoPrimaryKey if name has no pubkey author or several.
+func AddressAuthorForName(name Name, r OwnerResolver, weight uint64) (*Author, error) {
+	authors, err := r.ResolveAuthors(name)
+	if err != nil {
+		return nil, err
+	}
+	var primary *PubKey
+	for _, a := range authors {
+		pubKey, ok := a.Owner.(PubKey)
+		if !ok {
+			continue
+		}
+		if primary != nil {
+			return nil, fmt.Errorf("%w: %s has several pubkey authors", ErrNoPrimaryKey, name)
+		}
+		primary = &pubKey
+	}
+	if primary == nil {
+		return nil, fmt.Errorf("%w: %s has no pubkey author", ErrNoPrimaryKey, name)
+	}
+	address, err := PubKeyToAddress(*primary)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthor(address, weight), nil
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/browser/crypto"
+)
+
+type mapResolver map[Name][]*Author
+
+func (m mapResolver) ResolveAuthors(name Name) ([]*Author, error) {
+	authors, ok := m[name]
+	if !ok {
+		return nil, errors.New("unknown account " + name.String())
+	}
+	return authors, nil
+}
+
+func TestDetectAliasedAuthority(t *testing.T) {
+	key := HexToPubKey(testPubKeyHex)
+	r := mapResolver{
+		"alice":   {NewAuthor(key, 1)},
+		"treasur": {NewAuthor(Name("alice"), 1)},
+		"bob":     {NewAuthor(BytesToAddress([]byte{1}), 1)},
+		"loop":    {NewAuthor(Name("loop"), 1)},
+	}
+	authors := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("treasur"), 1),
+		NewAuthor(Name("loop"), 1),
+	}
+	groups, err := DetectAliasedAuthority(authors, r, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || len(groups[0]) != 2 {
+		t.Fatalf("got %d groups, want one pair", len(groups))
+	}
+	if groups[0][0].Owner != Name("alice") || groups[0][1].Owner != Name("treasur") {
+		t.Errorf("got group %v, %v, want alice, treasur", groups[0][0].Owner, groups[0][1].Owner)
+	}
+
+	if _, err := DetectAliasedAuthority(authors, r, 1); !errors.Is(err, ErrResolveDepth) {
+		t.Errorf("got %v, want %v", err, ErrResolveDepth)
+	}
+}
+
+func TestAddressAuthorForName(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := BytesToPubKey(crypto.FromECDSAPub(&key.PublicKey))
+	r := mapResolver{
+		"alice": {NewAuthor(pubKey, 1), NewAuthor(Name("bob"), 1)},
+		"multi": {NewAuthor(pubKey, 1), NewAuthor(HexToPubKey(testPubKeyHex), 1)},
+		"bob":   {NewAuthor(Name("alice"), 1)},
+	}
+
+	a, err := AddressAuthorForName("alice", r, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BytesToAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+	if a.Owner != want || a.Weight != 2 {
+		t.Errorf("got %v/%d, want %v/2", a.Owner, a.Weight, want)
+	}
+
+	for _, name := range []Name{"multi", "bob"} {
+		if _, err := AddressAuthorForName(name, r, 1); !errors.Is(err, ErrNoPrimaryKey) {
+			t.Errorf("%s: got %v, want %v", name, err, ErrNoPrimaryKey)
+		}
+	}
+}
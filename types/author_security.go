@@ -0,0 +1,96 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoSafeThreshold = errors.New("no threshold keeps every author from acting alone")
+
+// SecurityPosture summarizes how safely an account's control is distributed.
+// Each field is also available through the function named in its comment.
+type SecurityPosture struct {
+	Dominant         []*Author `json:"dominant"`         // DominantAuthors(authors, threshold)
+	Critical         []*Author `json:"critical"`         // CriticalAuthors(authors, threshold)
+	SwingWeight      uint64    `json:"swingWeight"`      // SwingWeight(authors, threshold)
+	Concentration    float64   `json:"concentration"`    // ConcentrationIndex(authors)
+	SingleKeyControl bool      `json:"singleKeyControl"` // len(DominantAuthors(authors, max(threshold, updateThreshold))) > 0
+}
+
+// DominantAuthors returns the authors whose weight alone meets threshold.
+func DominantAuthors(authors []*Author, threshold uint64) []*Author {
+	dominant := make([]*Author, 0)
+	for _, a := range authors {
+		if a.Weight >= threshold {
+			dominant = append(dominant, a)
+		}
+	}
+	return dominant
+}
+
+// SwingWeight returns how much weight the set can lose while still reaching
+// threshold, or 0 if it cannot reach it at all.
+func SwingWeight(authors []*Author, threshold uint64) uint64 {
+	total, err := TotalWeight(authors)
+	if err != nil || total < threshold {
+		return 0
+	}
+	return total - threshold
+}
+
+// ConcentrationIndex returns the Herfindahl-Hirschman index of the authors'
+// weight shares: the sum of the squared shares, from 1/n for n equal authors
+// up to 1 when a single author holds all the weight. An empty or weightless
+// set has index 0.
+func ConcentrationIndex(authors []*Author) float64 {
+	var total float64
+	for _, a := range authors {
+		total += float64(a.Weight)
+	}
+	if total == 0 {
+		return 0
+	}
+	var index float64
+	for _, a := range authors {
+		share := float64(a.Weight) / total
+		index += share * share
+	}
+	return index
+}
+
+// SecurityReport combines the security analyses of authors under the given
+// spending and update thresholds.
+func SecurityReport(authors []*Author, threshold, updateThreshold uint64) SecurityPosture {
+	both := threshold
+	if updateThreshold > both {
+		both = updateThreshold
+	}
+	return SecurityPosture{
+		Dominant:         DominantAuthors(authors, threshold),
+		Critical:         CriticalAuthors(authors, threshold),
+		SwingWeight:      SwingWeight(authors, threshold),
+		Concentration:    ConcentrationIndex(authors),
+		SingleKeyControl: len(DominantAuthors(authors, both)) > 0,
+	}
+}
+
+// SafeThreshold returns the smallest threshold that no single author can meet
+// alone, one more than the largest weight, provided the whole set can still
+// reach it. It returns ErrNoSafeThreshold when the largest author holds all
+// the weight.
+func SafeThreshold(authors []*Author) (uint64, error) {
+	total, err := TotalWeight(authors)
+	if err != nil {
+		return 0, err
+	}
+	var max uint64
+	for _, a := range authors {
+		if a.Weight > max {
+			max = a.Weight
+		}
+	}
+	if max >= total {
+		return 0, fmt.Errorf("%w: largest weight %d of total %d", ErrNoSafeThreshold, max, total)
+	}
+	return max + 1, nil
+}
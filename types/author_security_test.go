@@ -0,0 +1,69 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSecurityReport(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 4),
+		NewAuthor(Name("bob"), 2),
+		NewAuthor(Name("carol"), 1),
+		NewAuthor(Name("dave"), 1),
+	}
+	report := SecurityReport(authors, 4, 5)
+
+	if len(report.Dominant) != 1 || report.Dominant[0].Owner != Name("alice") {
+		t.Errorf("dominant: got %d authors, want alice", len(report.Dominant))
+	}
+	if len(report.Critical) != 0 {
+		t.Errorf("critical: got %d authors, want none", len(report.Critical))
+	}
+	if report.SwingWeight != 4 {
+		t.Errorf("swing weight: got %d, want 4", report.SwingWeight)
+	}
+	if want := (16.0 + 4 + 1 + 1) / 64; report.Concentration != want {
+		t.Errorf("concentration: got %v, want %v", report.Concentration, want)
+	}
+	if report.SingleKeyControl {
+		t.Errorf("single key control with update threshold 5, want none")
+	}
+
+	if report := SecurityReport(authors, 4, 4); !report.SingleKeyControl {
+		t.Errorf("alice cannot both spend and update with thresholds 4/4")
+	}
+	if critical := SecurityReport(authors, 7, 7).Critical; len(critical) != 2 {
+		t.Errorf("threshold 7: got %d critical authors, want alice and bob", len(critical))
+	}
+}
+
+func TestSafeThreshold(t *testing.T) {
+	weights := func(ws ...uint64) []*Author {
+		authors := make([]*Author, len(ws))
+		for i, w := range ws {
+			authors[i] = NewAuthor(BytesToAddress([]byte{byte(i)}), w)
+		}
+		return authors
+	}
+	tests := []struct {
+		authors []*Author
+		want    uint64
+	}{
+		{weights(1, 1, 1), 2},
+		{weights(5, 1, 1), 6},
+		{weights(3, 3), 4},
+		{weights(2, 0, 1), 3},
+	}
+	for i, test := range tests {
+		got, err := SafeThreshold(test.authors)
+		if err != nil || got != test.want {
+			t.Errorf("test %d: got %d, %v, want %d", i, got, err, test.want)
+		}
+	}
+	for _, authors := range [][]*Author{nil, weights(4), weights(4, 0)} {
+		if _, err := SafeThreshold(authors); !errors.Is(err, ErrNoSafeThreshold) {
+			t.Errorf("%d authors: got %v, want %v", len(authors), err, ErrNoSafeThreshold)
+		}
+	}
+}
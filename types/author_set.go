@@ -0,0 +1,305 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	ErrWeightOverflow          = errors.New("author weight overflow")
+	ErrAuthorExists            = errors.New("author already exists")
+	ErrAuthorNotFound          = errors.New("author not found")
+	ErrInvalidAuthorActionType = errors.New("invalid author action type")
+	ErrTotalWeightMismatch     = errors.New("total author weight mismatch")
+	ErrNilAuthorAction         = errors.New("nil author action")
+)
+
+// TotalWeight returns the sum of the authors' weights.
+func TotalWeight(authors []*Author) (uint64, error) {
+	var total uint64
+	for _, a := range authors {
+		if total > math.MaxUint64-a.Weight {
+			return 0, ErrWeightOverflow
+		}
+		total += a.Weight
+	}
+	return total, nil
+}
+
+// WeightByType returns the total weight held by each owner type. A total that
+// would overflow is capped at math.MaxUint64. Owners of unknown type are
+// skipped.
+func WeightByType(authors []*Author) map[AuthorType]uint64 {
+	weights := make(map[AuthorType]uint64)
+	for _, a := range authors {
+		at, ok := OwnerType(a.Owner)
+		if !ok {
+			continue
+		}
+		if weights[at] > math.MaxUint64-a.Weight {
+			weights[at] = math.MaxUint64
+			continue
+		}
+		weights[at] += a.Weight
+	}
+	return weights
+}
+
+// compareOwners orders owners by type, then payload.
+func compareOwners(a, b Owner) int {
+	at, _ := OwnerType(a)
+	bt, _ := OwnerType(b)
+	if at != bt {
+		if at < bt {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(ownerBytes(a), ownerBytes(b))
+}
+
+// VerifyTotalWeight checks that the authors' weights add up to expected, the
+// total cached alongside them.
+func VerifyTotalWeight(authors []*Author, expected uint64) error {
+	total, err := TotalWeight(authors)
+	if err != nil {
+		return err
+	}
+	if total != expected {
+		return fmt.Errorf("%w: computed %d, expected %d", ErrTotalWeightMismatch, total, expected)
+	}
+	return nil
+}
+
+func lessAuthor(a, b *Author) bool {
+	if c := compareOwners(a.Owner, b.Owner); c != 0 {
+		return c < 0
+	}
+	return a.Weight < b.Weight
+}
+
+// SortKey returns a byte key whose bytes.Compare order matches SortAuthors,
+// for external systems such as database indexes. The layout is
+//
+//	type (1 byte) | escaped payload | 0x00 0x00 | weight (8 bytes, big-endian)
+//
+// where each 0x00 byte of the owner payload is escaped as 0x00 0xff, so that
+// a payload always sorts before any longer payload it prefixes.
+func (a *Author) SortKey() []byte {
+	at, _ := OwnerType(a.Owner)
+	payload := ownerBytes(a.Owner)
+	key := make([]byte, 0, 1+len(payload)+2+8)
+	key = append(key, byte(at))
+	for _, b := range payload {
+		key = append(key, b)
+		if b == 0 {
+			key = append(key, 0xff)
+		}
+	}
+	key = append(key, 0, 0)
+	var weight [8]byte
+	binary.BigEndian.PutUint64(weight[:], a.Weight)
+	return append(key, weight[:]...)
+}
+
+// SortAuthors sorts authors into canonical order: by owner type, then owner
+// payload compared with bytes.Compare, then weight. Distinct owners always
+// differ in type or payload, so the order is total and never depends on the
+// input order; only exact duplicates compare equal.
+func SortAuthors(authors []*Author) {
+	sort.SliceStable(authors, func(i, j int) bool {
+		return lessAuthor(authors[i], authors[j])
+	})
+}
+
+// IsCanonical reports whether authors is in canonical order with no
+// duplicate owners, without sorting it.
+func IsCanonical(authors []*Author) bool {
+	for i := 1; i < len(authors); i++ {
+		if compareOwners(authors[i-1].Owner, authors[i].Owner) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AuthorsEqual reports whether a and b hold the same authors, regardless of
+// order.
+func AuthorsEqual(a, b []*Author) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as, bs := copyAuthors(a), copyAuthors(b)
+	SortAuthors(as)
+	SortAuthors(bs)
+	for i := range as {
+		if !as[i].Equal(bs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func copyAuthors(authors []*Author) []*Author {
+	cpy := make([]*Author, len(authors))
+	for i, a := range authors {
+		cpy[i] = &Author{Owner: a.Owner, Weight: a.Weight}
+	}
+	return cpy
+}
+
+func indexOfOwner(authors []*Author, owner Owner) int {
+	key := OwnerKey(owner)
+	for i, a := range authors {
+		if OwnerKey(a.Owner) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// ApplyActions returns the author set that results from applying actions to
+// current in order. Like the node, it rejects adding an existing owner and
+// updating or deleting a missing one. current is not modified.
+func ApplyActions(current []*Author, actions []*AuthorAction) ([]*Author, error) {
+	authors := copyAuthors(current)
+	for i, action := range actions {
+		if action == nil {
+			return nil, fmt.Errorf("author action %d: %w", i, ErrNilAuthorAction)
+		}
+		if action.Author == nil || action.Author.Owner == nil {
+			return nil, fmt.Errorf("author action %d: %w", i, ErrInvalidOwner)
+		}
+		idx := indexOfOwner(authors, action.Author.Owner)
+		switch action.ActionType {
+		case AddAuthor:
+			if idx >= 0 {
+				return nil, fmt.Errorf("author action %d: %w: %s", i, ErrAuthorExists, action.Author.Owner)
+			}
+			authors = append(authors, &Author{Owner: action.Author.Owner, Weight: action.Author.Weight})
+		case UpdateAuthor:
+			if idx < 0 {
+				return nil, fmt.Errorf("author action %d: %w: %s", i, ErrAuthorNotFound, action.Author.Owner)
+			}
+			authors[idx].Weight = action.Author.Weight
+		case DeleteAuthor:
+			if idx < 0 {
+				return nil, fmt.Errorf("author action %d: %w: %s", i, ErrAuthorNotFound, action.Author.Owner)
+			}
+			authors = append(authors[:idx], authors[idx+1:]...)
+		default:
+			return nil, fmt.Errorf("author action %d: %w: %d", i, ErrInvalidAuthorActionType, action.ActionType)
+		}
+	}
+	return authors, nil
+}
+
+// DiffAuthors returns the minimal actions turning prev into next: deletions,
+// then weight updates, then additions, each group in canonical author order.
+func DiffAuthors(prev, next []*Author) []*AuthorAction {
+	var deletes, updates, adds []*Author
+	for _, a := range prev {
+		if indexOfOwner(next, a.Owner) < 0 {
+			deletes = append(deletes, &Author{Owner: a.Owner})
+		}
+	}
+	for _, a := range next {
+		idx := indexOfOwner(prev, a.Owner)
+		switch {
+		case idx < 0:
+			adds = append(adds, &Author{Owner: a.Owner, Weight: a.Weight})
+		case prev[idx].Weight != a.Weight:
+			updates = append(updates, &Author{Owner: a.Owner, Weight: a.Weight})
+		}
+	}
+	actions := make([]*AuthorAction, 0, len(deletes)+len(updates)+len(adds))
+	for _, group := range []struct {
+		actionType AuthorActionType
+		authors    []*Author
+	}{{DeleteAuthor, deletes}, {UpdateAuthor, updates}, {AddAuthor, adds}} {
+		SortAuthors(group.authors)
+		for _, a := range group.authors {
+			actions = append(actions, &AuthorAction{ActionType: group.actionType, Author: a})
+		}
+	}
+	return actions
+}
+
+// SnapshotsToChangelog returns the diffs between consecutive snapshots, so a
+// history can be stored as its first snapshot plus the changelog. Folding the
+// changelog over the first snapshot with ApplyActions yields the others.
+func SnapshotsToChangelog(snapshots [][]*Author) [][]*AuthorAction {
+	if len(snapshots) < 2 {
+		return nil
+	}
+	changelog := make([][]*AuthorAction, len(snapshots)-1)
+	for i := range changelog {
+		changelog[i] = DiffAuthors(snapshots[i], snapshots[i+1])
+	}
+	return changelog
+}
+
+// UnionAuthors returns the authors of all sets with duplicate owners merged.
+// When an owner appears with different weights, the largest weight is kept.
+// The result is in canonical order.
+func UnionAuthors(sets ...[]*Author) []*Author {
+	union := make([]*Author, 0)
+	index := make(map[string]int)
+	for _, set := range sets {
+		for _, a := range set {
+			key := OwnerKey(a.Owner)
+			if i, ok := index[key]; ok {
+				if a.Weight > union[i].Weight {
+					union[i].Weight = a.Weight
+				}
+				continue
+			}
+			index[key] = len(union)
+			union = append(union, &Author{Owner: a.Owner, Weight: a.Weight})
+		}
+	}
+	SortAuthors(union)
+	return union
+}
+
+// AuthorSetSimilarity returns the Jaccard index of the owners of a and b, the
+// size of their intersection over the size of their union. Weights are
+// ignored. Two empty sets are identical and have similarity 1.
+func AuthorSetSimilarity(a, b []*Author) float64 {
+	owners := make(map[string]int)
+	for _, author := range a {
+		owners[OwnerKey(author.Owner)] |= 1
+	}
+	for _, author := range b {
+		owners[OwnerKey(author.Owner)] |= 2
+	}
+	if len(owners) == 0 {
+		return 1
+	}
+	var intersection int
+	for _, in := range owners {
+		if in == 3 {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(owners))
+}
+
+// PrimaryAuthor returns the author with the highest weight, breaking ties by
+// canonical owner order. It returns false for an empty set.
+func PrimaryAuthor(authors []*Author) (*Author, bool) {
+	if len(authors) == 0 {
+		return nil, false
+	}
+	primary := authors[0]
+	for _, a := range authors[1:] {
+		if a.Weight > primary.Weight || (a.Weight == primary.Weight && compareOwners(a.Owner, primary.Owner) < 0) {
+			primary = a
+		}
+	}
+	return primary, true
+}
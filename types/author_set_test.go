@@ -0,0 +1,223 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestUnionAuthors(t *testing.T) {
+	a := []*Author{NewAuthor(Name("alice"), 1), NewAuthor(Name("bob"), 3)}
+	b := []*Author{NewAuthor(Name("bob"), 2), NewAuthor(Name("carol"), 1)}
+	c := []*Author{NewAuthor(Name("alice"), 4)}
+
+	got := UnionAuthors(a, b, c)
+	want := []*Author{NewAuthor(Name("alice"), 4), NewAuthor(Name("bob"), 3), NewAuthor(Name("carol"), 1)}
+	if len(got) != len(want) {
+		t.Fatalf("got %d authors, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("author %d: got %v/%d, want %v/%d", i, got[i].Owner, got[i].Weight, want[i].Owner, want[i].Weight)
+		}
+	}
+	if a[0].Weight != 1 {
+		t.Errorf("input author modified")
+	}
+	if len(UnionAuthors()) != 0 {
+		t.Errorf("union of no sets is not empty")
+	}
+}
+
+func TestVerifyTotalWeight(t *testing.T) {
+	authors := []*Author{NewAuthor(Name("alice"), 2), NewAuthor(Name("bob"), 3)}
+	if err := VerifyTotalWeight(authors, 5); err != nil {
+		t.Errorf("matching total: %v", err)
+	}
+	err := VerifyTotalWeight(authors, 4)
+	if !errors.Is(err, ErrTotalWeightMismatch) {
+		t.Fatalf("got %v, want %v", err, ErrTotalWeightMismatch)
+	}
+	if !strings.Contains(err.Error(), "computed 5, expected 4") {
+		t.Errorf("error %q does not report both totals", err)
+	}
+	if err := VerifyTotalWeight(nil, 0); err != nil {
+		t.Errorf("empty set: %v", err)
+	}
+}
+
+func TestAuthorSetSimilarity(t *testing.T) {
+	alice, bob := NewAuthor(Name("alice"), 1), NewAuthor(Name("bob"), 1)
+	carol, dave := NewAuthor(Name("carol"), 1), NewAuthor(Name("dave"), 1)
+	tests := []struct {
+		a, b []*Author
+		want float64
+	}{
+		{nil, nil, 1},
+		{[]*Author{alice, bob}, []*Author{bob, NewAuthor(Name("alice"), 5)}, 1},
+		{[]*Author{alice, bob}, []*Author{carol, dave}, 0},
+		{[]*Author{alice, bob, carol}, []*Author{bob, carol, dave}, 0.5},
+		{[]*Author{alice}, nil, 0},
+	}
+	for i, test := range tests {
+		if got := AuthorSetSimilarity(test.a, test.b); got != test.want {
+			t.Errorf("test %d: got %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestSortAuthorsPayloadTieBreak(t *testing.T) {
+	var want []*Author
+	for _, b := range []byte{0x01, 0x02, 0x10, 0x80, 0xff} {
+		want = append(want, NewAuthor(BytesToAddress([]byte{b, 0}), 1))
+	}
+	for _, perm := range [][]int{{4, 3, 2, 1, 0}, {2, 0, 4, 1, 3}, {1, 3, 0, 4, 2}} {
+		authors := make([]*Author, len(perm))
+		for i, j := range perm {
+			authors[i] = want[j]
+		}
+		SortAuthors(authors)
+		for i := range want {
+			if authors[i] != want[i] {
+				t.Errorf("permutation %v: position %d holds %v, want %v", perm, i, authors[i].Owner, want[i].Owner)
+			}
+		}
+	}
+}
+
+func TestPrimaryAuthor(t *testing.T) {
+	if _, ok := PrimaryAuthor(nil); ok {
+		t.Errorf("empty set has a primary author")
+	}
+	authors := []*Author{
+		NewAuthor(Name("carol"), 2),
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(HexToPubKey(testPubKeyHex), 3),
+	}
+	if a, ok := PrimaryAuthor(authors); !ok || a != authors[2] {
+		t.Errorf("got %v, want the pubkey author", a.Owner)
+	}
+
+	authors[1].Weight = 3
+	for _, order := range [][]*Author{authors, {authors[2], authors[1], authors[0]}} {
+		if a, _ := PrimaryAuthor(order); a != authors[1] {
+			t.Errorf("weight tie: got %v, want alice", a.Owner)
+		}
+	}
+}
+
+func TestSortKeyMatchesSortAuthors(t *testing.T) {
+	authors := GenerateTestAuthors(30, 11)
+	authors = append(authors,
+		NewAuthor(Name("ab"), math.MaxUint64),
+		NewAuthor(Name("abc"), 1),
+		NewAuthor(Name("ab"), 1),
+		NewAuthor(BytesToAddress([]byte{0, 0, 1}), 5),
+		NewAuthor(BytesToAddress([]byte{1}), 5),
+	)
+	sorted := copyAuthors(authors)
+	SortAuthors(sorted)
+	byKey := copyAuthors(authors)
+	sort.Slice(byKey, func(i, j int) bool {
+		return bytes.Compare(byKey[i].SortKey(), byKey[j].SortKey()) < 0
+	})
+	for i := range sorted {
+		if !sorted[i].Equal(byKey[i]) {
+			t.Errorf("position %d: SortAuthors has %v/%d, SortKey has %v/%d",
+				i, sorted[i].Owner, sorted[i].Weight, byKey[i].Owner, byKey[i].Weight)
+		}
+	}
+}
+
+func TestApplyNilAction(t *testing.T) {
+	current := []*Author{NewAuthor(Name("alice"), 1)}
+	var action AccountAuthorAction
+	if err := json.Unmarshal([]byte(`{"authorActions":[null]}`), &action); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := action.Apply(current); !errors.Is(err, ErrNilAuthorAction) {
+		t.Errorf("null action: got %v, want %v", err, ErrNilAuthorAction)
+	}
+	if _, err := action.CanonicalJSON(); !errors.Is(err, ErrNilAuthorAction) {
+		t.Errorf("canonical JSON of null action: got %v, want %v", err, ErrNilAuthorAction)
+	}
+	actions := []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("bob"), 1)}, nil}
+	if _, err := ApplyActions(current, actions); !errors.Is(err, ErrNilAuthorAction) {
+		t.Errorf("nil second action: got %v, want %v", err, ErrNilAuthorAction)
+	}
+}
+
+func TestSnapshotsToChangelog(t *testing.T) {
+	snapshots := [][]*Author{
+		{NewAuthor(Name("alice"), 1), NewAuthor(Name("bob"), 1)},
+		{NewAuthor(Name("alice"), 2), NewAuthor(Name("bob"), 1), NewAuthor(Name("carol"), 1)},
+		{NewAuthor(Name("carol"), 3), NewAuthor(Name("dave"), 1)},
+	}
+	changelog := SnapshotsToChangelog(snapshots)
+	if len(changelog) != len(snapshots)-1 {
+		t.Fatalf("got %d steps, want %d", len(changelog), len(snapshots)-1)
+	}
+	authors := snapshots[0]
+	for i, step := range changelog {
+		var err error
+		if authors, err = ApplyActions(authors, step); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+		if !AuthorsEqual(authors, snapshots[i+1]) {
+			t.Errorf("step %d: reconstructed snapshot differs", i)
+		}
+	}
+
+	if changelog := SnapshotsToChangelog(snapshots[:1]); changelog != nil {
+		t.Errorf("single snapshot: got %d steps, want none", len(changelog))
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	pub := HexToPubKey(testPubKeyHex)
+	tests := []struct {
+		name    string
+		authors []*Author
+		want    bool
+	}{
+		{"empty", nil, true},
+		{"canonical", []*Author{NewAuthor(Name("alice"), 3), NewAuthor(Name("bob"), 1), NewAuthor(pub, 2)}, true},
+		{"misordered", []*Author{NewAuthor(Name("bob"), 1), NewAuthor(Name("alice"), 3)}, false},
+		{"misordered types", []*Author{NewAuthor(pub, 2), NewAuthor(Name("alice"), 3)}, false},
+		{"duplicate owner", []*Author{NewAuthor(Name("alice"), 1), NewAuthor(Name("alice"), 2)}, false},
+	}
+	for _, test := range tests {
+		if got := IsCanonical(test.authors); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestWeightByType(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 1),
+		NewAuthor(Name("bob"), 2),
+		NewAuthor(HexToPubKey(testPubKeyHex), 4),
+		NewAuthor(BytesToAddress([]byte{1}), 8),
+		NewAuthor(BytesToAddress([]byte{2}), 16),
+	}
+	weights := WeightByType(authors)
+	want := map[AuthorType]uint64{AccountNameType: 3, PubKeyType: 4, AddressType: 24}
+	if len(weights) != len(want) {
+		t.Errorf("got %d types, want %d", len(weights), len(want))
+	}
+	for at, w := range want {
+		if weights[at] != w {
+			t.Errorf("%s: got %d, want %d", AuthorTypeToString[at], weights[at], w)
+		}
+	}
+
+	overflow := []*Author{NewAuthor(Name("alice"), math.MaxUint64), NewAuthor(Name("bob"), 1)}
+	if got := WeightByType(overflow)[AccountNameType]; got != math.MaxUint64 {
+		t.Errorf("overflow: got %d, want %d", got, uint64(math.MaxUint64))
+	}
+}
@@ -0,0 +1,362 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/browser/rlp"
+	"github.com/fractalplatform/fractal/common"
+)
+
+func TestDecodeRLPStrict(t *testing.T) {
+	zero, err := rlp.EncodeToBytes(NewAuthor(Name("alice"), 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lenient := new(Author)
+	if err := rlp.DecodeBytes(zero, lenient); err != nil {
+		t.Fatalf("lenient decode: %v", err)
+	}
+	if lenient.Owner != Name("alice") || lenient.Weight != 0 {
+		t.Errorf("lenient decode: got %v/%d", lenient.Owner, lenient.Weight)
+	}
+	strict := new(Author)
+	if err := strict.DecodeRLPStrict(rlp.NewStream(bytes.NewReader(zero), 0)); !errors.Is(err, ErrZeroWeightAuthor) {
+		t.Errorf("strict decode: got %v, want %v", err, ErrZeroWeightAuthor)
+	}
+
+	one, err := rlp.EncodeToBytes(NewAuthor(Name("alice"), 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := strict.DecodeRLPStrict(rlp.NewStream(bytes.NewReader(one), 0)); err != nil {
+		t.Errorf("strict decode: %v", err)
+	}
+}
+
+func TestRedactWeights(t *testing.T) {
+	authors := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(HexToPubKey(testPubKeyHex), 3),
+	}
+	redacted := RedactWeights(authors)
+	if len(redacted) != len(authors) {
+		t.Fatalf("got %d authors, want %d", len(redacted), len(authors))
+	}
+	for i, r := range redacted {
+		if OwnerKey(r.Owner) != OwnerKey(authors[i].Owner) || r.Weight != 0 {
+			t.Errorf("author %d: got %v/%d, want %v/0", i, r.Owner, r.Weight, authors[i].Owner)
+		}
+	}
+	if authors[0].Weight != 2 || authors[1].Weight != 3 {
+		t.Errorf("original weights modified")
+	}
+}
+
+func TestAuthorReadOnly(t *testing.T) {
+	key, err := NewExtendedPubKey(bytes.Repeat([]byte{1}, 48))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewAuthor(key, 2)
+	view := a.ReadOnly()
+	if view.Type() != ExtendedPubKeyType || view.OwnerString() != key.String() || view.Weight() != 2 {
+		t.Errorf("got view %d/%s/%d", view.Type(), view.OwnerString(), view.Weight())
+	}
+
+	b := view.Bytes()
+	b[0] = 0xff
+	if key[0] != 1 || view.Bytes()[0] != 1 {
+		t.Errorf("modifying view bytes changed the author")
+	}
+
+	a.Weight = 3
+	if view.Weight() != 3 {
+		t.Errorf("view weight %d does not reflect author weight 3", view.Weight())
+	}
+}
+
+func TestGenerateTestAuthors(t *testing.T) {
+	a := GenerateTestAuthors(9, 42)
+	b := GenerateTestAuthors(9, 42)
+	if len(a) != 9 {
+		t.Fatalf("got %d authors, want 9", len(a))
+	}
+	types := make(map[AuthorType]int)
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			t.Errorf("author %d differs between runs: %v/%d, %v/%d", i, a[i].Owner, a[i].Weight, b[i].Owner, b[i].Weight)
+		}
+		if a[i].Weight < 1 || a[i].Weight > 10 {
+			t.Errorf("author %d has weight %d", i, a[i].Weight)
+		}
+		at, _ := OwnerType(a[i].Owner)
+		types[at]++
+	}
+	if types[AccountNameType] != 3 || types[PubKeyType] != 3 || types[AddressType] != 3 {
+		t.Errorf("got owner types %v, want 3 of each", types)
+	}
+	if AuthorsEqual(a, GenerateTestAuthors(9, 43)) {
+		t.Errorf("different seeds produced the same authors")
+	}
+}
+
+func TestVerifyEncodingsMatch(t *testing.T) {
+	encode := func(a *Author) ([]byte, []byte) {
+		j, err := a.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := rlp.EncodeToBytes(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j, r
+	}
+	authors := GenerateTestAuthors(3, 5)
+	for _, a := range authors {
+		j, r := encode(a)
+		if err := VerifyEncodingsMatch(j, r); err != nil {
+			t.Errorf("%v: %v", a.Owner, err)
+		}
+	}
+
+	j, _ := encode(authors[0])
+	_, r := encode(NewAuthor(authors[0].Owner, authors[0].Weight+1))
+	if err := VerifyEncodingsMatch(j, r); !errors.Is(err, ErrEncodingMismatch) {
+		t.Errorf("different weight: got %v, want %v", err, ErrEncodingMismatch)
+	}
+	j, _ = encode(authors[1])
+	_, r = encode(authors[2])
+	if err := VerifyEncodingsMatch(j, r); !errors.Is(err, ErrEncodingMismatch) {
+		t.Errorf("different owner type: got %v, want %v", err, ErrEncodingMismatch)
+	}
+}
+
+func TestDecodeAuthorsFraming(t *testing.T) {
+	authors := GenerateTestAuthors(4, 3)
+	values := make([]StorageAuthor, len(authors))
+	pointers := make([]*StorageAuthor, len(authors))
+	for i, a := range authors {
+		sa, err := a.encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values[i], pointers[i] = *sa, sa
+	}
+	want, err := rlp.EncodeToBytes(authors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []interface{}{values, pointers} {
+		enc, err := rlp.EncodeToBytes(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(enc, want) {
+			t.Errorf("%T encodes as %x, want %x", v, enc, want)
+		}
+		got, err := DecodeAuthors(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(authors) {
+			t.Fatalf("%T: got %d authors, want %d", v, len(got), len(authors))
+		}
+		for i := range authors {
+			if !got[i].Equal(authors[i]) {
+				t.Errorf("%T: author %d: got %v, want %v", v, i, got[i].Owner, authors[i].Owner)
+			}
+		}
+	}
+}
+
+func TestDecodeAuthorsEmpty(t *testing.T) {
+	enc, err := rlp.EncodeToBytes([]*Author{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nilEnc, err := rlp.EncodeToBytes([]*Author(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, nilEnc) {
+		t.Errorf("empty list encodes as %x, nil list as %x", enc, nilEnc)
+	}
+	got, err := DecodeAuthors(enc)
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("encoded empty list: got %v, %v, want empty non-nil slice", got, err)
+	}
+	got, err = DecodeAuthors(nil)
+	if err != nil || got != nil {
+		t.Errorf("no input: got %v, %v, want nil", got, err)
+	}
+}
+
+func TestAuthorMarshalJSONMatchesFractal(t *testing.T) {
+	pub := HexToPubKey(testPubKeyHex)
+	addr := BytesToAddress(FromHex("0x9858effd232b4033e47d90003d41ec34ecaeda94"))
+	tests := []struct {
+		author  *Author
+		fractal *common.Author
+	}{
+		{NewAuthor(Name("alice"), 1), common.NewAuthor(common.Name("alice"), 1)},
+		{NewAuthor(pub, 2), common.NewAuthor(common.HexToPubKey(testPubKeyHex), 2)},
+		{NewAuthor(addr, 3), common.NewAuthor(common.HexToAddress(addr.Hex()), 3)},
+	}
+	for _, test := range tests {
+		got, err := json.Marshal(test.author)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := json.Marshal(test.fractal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		decoded := new(Author)
+		if err := json.Unmarshal(want, decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(test.author) {
+			t.Errorf("%s: decoded %T %v, want %T %v", want, decoded.Owner, decoded.Owner, test.author.Owner, test.author.Owner)
+		}
+	}
+}
+
+// decodeCorpus returns RLP-encoded StorageAuthor blobs for seeding decode
+// fuzzers. It covers every owner type at valid and off-by-one payload
+// lengths, zero and maximum weights, an empty DataRaw, an unknown type and
+// truncated or malformed encodings. The corpus is the same on every call.
+func decodeCorpus(tb testing.TB) [][]byte {
+	tb.Helper()
+	payload := func(v interface{}) rlp.RawValue {
+		enc, err := rlp.EncodeToBytes(v)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return enc
+	}
+	sized := func(n int) rlp.RawValue { return payload(bytes.Repeat([]byte{0xab}, n)) }
+
+	authors := []*StorageAuthor{
+		{Type: AccountNameType, DataRaw: payload(Name("alice")), Weight: 1},
+		{Type: AccountNameType, DataRaw: payload(Name("")), Weight: 1},
+		{Type: AccountNameType, DataRaw: payload(Name("alice")), Weight: 0},
+		{Type: AccountNameType, DataRaw: payload(Name("alice")), Weight: math.MaxUint64},
+		{Type: AccountNameType, Weight: 1},
+		{Type: PubKeyType, DataRaw: sized(PubKeyLength), Weight: 1},
+		{Type: PubKeyType, DataRaw: sized(PubKeyLength - 1), Weight: 1},
+		{Type: PubKeyType, DataRaw: sized(PubKeyLength + 1), Weight: 1},
+		{Type: PubKeyType, Weight: 1},
+		{Type: AddressType, DataRaw: sized(AddressLength), Weight: 1},
+		{Type: AddressType, DataRaw: sized(AddressLength - 1), Weight: 1},
+		{Type: AddressType, DataRaw: sized(AddressLength + 1), Weight: 1},
+		{Type: AddressType, DataRaw: sized(0), Weight: 0},
+		{Type: math.MaxUint8, DataRaw: sized(AddressLength), Weight: 1},
+	}
+	if info, ok := DefaultAuthorTypeRegistry.Lookup(ExtendedPubKeyType); ok {
+		for _, n := range info.Lengths {
+			authors = append(authors,
+				&StorageAuthor{Type: ExtendedPubKeyType, DataRaw: sized(n), Weight: 1},
+				&StorageAuthor{Type: ExtendedPubKeyType, DataRaw: sized(n + 1), Weight: 1},
+			)
+		}
+	}
+
+	corpus := make([][]byte, 0, len(authors)+4)
+	for _, sa := range authors {
+		corpus = append(corpus, payload(sa))
+	}
+	valid := corpus[0]
+	return append(corpus,
+		valid[:len(valid)-1],                           // truncated
+		append(append([]byte{}, valid...), 0x80),       // trailing data
+		payload([]interface{}{uint(0), "alice"}),       // missing weight
+		payload([]interface{}{[]uint{0}, "", uint(1)}), // list where type is expected
+	)
+}
+
+func TestDecodeCorpus(t *testing.T) {
+	corpus := decodeCorpus(t)
+	if len(corpus) == 0 {
+		t.Fatal("empty corpus")
+	}
+	var decoded int
+	for _, seed := range corpus {
+		var a Author
+		if rlp.DecodeBytes(seed, &a) == nil {
+			decoded++
+		}
+	}
+	if decoded == 0 || decoded == len(corpus) {
+		t.Errorf("%d of %d seeds decode, want a mix of valid and invalid seeds", decoded, len(corpus))
+	}
+}
+
+func FuzzAuthorDecodeRLP(f *testing.F) {
+	for _, seed := range decodeCorpus(f) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var a Author
+		if err := rlp.DecodeBytes(data, &a); err != nil {
+			return
+		}
+		enc, err := rlp.EncodeToBytes(&a)
+		if err != nil {
+			t.Fatalf("re-encoding decoded author: %v", err)
+		}
+		var b Author
+		if err := rlp.DecodeBytes(enc, &b); err != nil {
+			t.Fatalf("decoding re-encoded author: %v", err)
+		}
+		if !a.Equal(&b) {
+			t.Errorf("round trip changed author: %v != %v", a, b)
+		}
+	})
+}
+
+func TestGenerateOwnerETrimsHex(t *testing.T) {
+	addr := BytesToAddress(FromHex("0x9858effd232b4033e47d90003d41ec34ecaeda94"))
+	owner, err := GenerateOwnerE(" 0x9858effd232b4033e47d90003d41ec34ecaeda94\n", AddressType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if OwnerKey(owner) != OwnerKey(addr) {
+		t.Errorf("got %v, want %v", owner, addr)
+	}
+	key := "0x" + strings.Repeat("ab", PubKeyLength)
+	if _, err := GenerateOwnerE(" "+key+" ", PubKeyType); err != nil {
+		t.Errorf("padded pubkey: %v", err)
+	}
+}
+
+func TestOwnerKeyCase(t *testing.T) {
+	if OwnerKey(Name("alice")) == OwnerKey(Name("ALICE")) {
+		t.Errorf("names differing in case share a key")
+	}
+	addr := BytesToAddress(FromHex("0x9858effd232b4033e47d90003d41ec34ecaeda94"))
+	mixed, err := GenerateOwnerE(addr.Hex(), AddressType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if OwnerKey(addr) != OwnerKey(mixed) {
+		t.Errorf("checksummed and plain addresses have different keys")
+	}
+
+	current := []*Author{NewAuthor(Name("alice"), 1)}
+	next, err := ApplyActions(current, []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("ALICE"), 1)}})
+	if err != nil {
+		t.Fatalf("adding ALICE to {alice}: %v", err)
+	}
+	if len(next) != 2 {
+		t.Errorf("got %d authors, want 2", len(next))
+	}
+}
@@ -0,0 +1,232 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrZeroThreshold        = errors.New("threshold is zero")
+	ErrThresholdUnreachable = errors.New("threshold exceeds total author weight")
+	ErrTooManyAuthors       = errors.New("too many authors")
+	ErrTooManyAuthorActions = errors.New("too many author actions")
+	ErrOwnerTooLong         = errors.New("author owner too long")
+	ErrDuplicateAuthor      = errors.New("duplicate author")
+	ErrUpdateLock           = errors.New("update author threshold unreachable, further author updates would be impossible")
+)
+
+// ValidateOptions configures ValidateAuthorization. Zero limits are not
+// enforced.
+type ValidateOptions struct {
+	// Threshold and UpdateAuthorThreshold are the account's current
+	// thresholds, which apply when the action leaves its own unset.
+	Threshold             uint64
+	UpdateAuthorThreshold uint64
+
+	MaxAuthors       int // maximum authors in the resulting set
+	MaxAuthorActions int // maximum author actions in a single update
+	MaxNameLength    int // maximum length of an account name owner
+}
+
+// AuthorizationResult is the outcome of a successful ValidateAuthorization.
+type AuthorizationResult struct {
+	Authors               []*Author `json:"authors"`
+	Threshold             uint64    `json:"threshold"`
+	UpdateAuthorThreshold uint64    `json:"updateAuthorThreshold"`
+	Warnings              []string  `json:"warnings,omitempty"`
+}
+
+// ValidateAuthorization applies action to current and validates the resulting
+// account authorization: both thresholds must be non-zero and reachable by the
+// new author set, the set must hold no duplicate owners, and the limits in
+// opts must be respected. An unreachable update author threshold is reported
+// as ErrUpdateLock, since it would freeze the author set for good. It returns
+// the would-be final authorization together with any non-fatal warnings, or
+// the first problem found.
+func ValidateAuthorization(current []*Author, action *AccountAuthorAction, opts ValidateOptions) (*AuthorizationResult, error) {
+	result, problems := validateAuthorization(current, action, opts)
+	if len(problems) != 0 {
+		return nil, problems[0]
+	}
+	return result, nil
+}
+
+// ValidateBatch validates each action against the author set at the same
+// index of current, using ValidateAuthorization, and returns one error per
+// action, nil for those that pass. It checks ctx before each action, so a
+// caller can bound the work with a deadline; if ctx is done it stops and
+// returns the errors found so far along with ctx.Err(), leaving the rest nil.
+func ValidateBatch(ctx context.Context, actions []*AccountAuthorAction, current [][]*Author, opts ValidateOptions) ([]error, error) {
+	if len(actions) != len(current) {
+		return nil, fmt.Errorf("got %d actions but %d author sets", len(actions), len(current))
+	}
+	errs := make([]error, len(actions))
+	for i, action := range actions {
+		if err := ctx.Err(); err != nil {
+			return errs, err
+		}
+		_, errs[i] = ValidateAuthorization(current[i], action, opts)
+	}
+	return errs, nil
+}
+
+// validateAuthorization runs every check and collects all problems found.
+func validateAuthorization(current []*Author, action *AccountAuthorAction, opts ValidateOptions) (*AuthorizationResult, []error) {
+	if action == nil {
+		return nil, []error{ErrNilAuthorAction}
+	}
+	var problems []error
+	if opts.MaxAuthorActions > 0 && len(action.AuthorActions) > opts.MaxAuthorActions {
+		problems = append(problems, fmt.Errorf("%w: %d actions, limit %d", ErrTooManyAuthorActions, len(action.AuthorActions), opts.MaxAuthorActions))
+	}
+	authors, err := action.Apply(current)
+	if err != nil {
+		return nil, append(problems, err)
+	}
+	result := &AuthorizationResult{
+		Authors:               authors,
+		Threshold:             opts.Threshold,
+		UpdateAuthorThreshold: opts.UpdateAuthorThreshold,
+	}
+	if action.Threshold != 0 {
+		result.Threshold = action.Threshold
+	}
+	if action.UpdateAuthorThreshold != 0 {
+		result.UpdateAuthorThreshold = action.UpdateAuthorThreshold
+	}
+
+	if opts.MaxAuthors > 0 && len(authors) > opts.MaxAuthors {
+		problems = append(problems, fmt.Errorf("%w: %d authors, limit %d", ErrTooManyAuthors, len(authors), opts.MaxAuthors))
+	}
+	seen := make(map[string]bool)
+	for _, a := range authors {
+		key := OwnerKey(a.Owner)
+		if seen[key] {
+			problems = append(problems, fmt.Errorf("%w: %s", ErrDuplicateAuthor, a.Owner))
+		}
+		seen[key] = true
+		if name, ok := a.Owner.(Name); ok && opts.MaxNameLength > 0 && len(name) > opts.MaxNameLength {
+			problems = append(problems, fmt.Errorf("%w: %s is %d bytes, limit %d", ErrOwnerTooLong, name, len(name), opts.MaxNameLength))
+		}
+		if a.Weight == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("author %s has zero weight", a.Owner))
+		}
+	}
+
+	total, err := TotalWeight(authors)
+	if err != nil {
+		return result, append(problems, err)
+	}
+	for _, th := range []struct {
+		name        string
+		value       uint64
+		unreachable error
+	}{
+		{"threshold", result.Threshold, ErrThresholdUnreachable},
+		{"update author threshold", result.UpdateAuthorThreshold, ErrUpdateLock},
+	} {
+		switch {
+		case th.value == 0:
+			problems = append(problems, fmt.Errorf("%w: %s", ErrZeroThreshold, th.name))
+		case th.value > total:
+			problems = append(problems, fmt.Errorf("%w: %s %d, total weight %d", th.unreachable, th.name, th.value, total))
+		case th.value == total && len(authors) > 1:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %d requires every author to sign", th.name, th.value))
+		}
+	}
+	return result, problems
+}
+
+// ExplainValidation runs the checks of ValidateAuthorization, collecting every
+// problem rather than stopping at the first, and renders them as a multi-line
+// explanation along with the resulting thresholds, warnings and lock-out
+// risks.
+func ExplainValidation(current []*Author, action *AccountAuthorAction, opts ValidateOptions) string {
+	result, problems := validateAuthorization(current, action, opts)
+	var b strings.Builder
+	if len(problems) == 0 {
+		b.WriteString("author update is valid\n")
+	} else {
+		fmt.Fprintf(&b, "author update rejected with %d problem(s):\n", len(problems))
+		for _, p := range problems {
+			fmt.Fprintf(&b, "  - %v\n", p)
+		}
+	}
+	if result == nil {
+		return b.String()
+	}
+	total, _ := TotalWeight(result.Authors)
+	fmt.Fprintf(&b, "resulting set: %d author(s), total weight %d, threshold %d, update author threshold %d\n",
+		len(result.Authors), total, result.Threshold, result.UpdateAuthorThreshold)
+	warnings := result.Warnings
+	for _, th := range []struct {
+		name  string
+		value uint64
+	}{{"threshold", result.Threshold}, {"update author threshold", result.UpdateAuthorThreshold}} {
+		for _, a := range CriticalAuthors(result.Authors, th.value) {
+			warnings = append(warnings, fmt.Sprintf("losing author %s would make the %s unreachable", a.Owner, th.name))
+		}
+	}
+	if len(warnings) != 0 {
+		b.WriteString("warnings:\n")
+		for _, w := range warnings {
+			fmt.Fprintf(&b, "  - %s\n", w)
+		}
+	}
+	return b.String()
+}
+
+var (
+	ErrTooFewAuthors       = errors.New("author set has too few authors")
+	ErrThresholdTooLow     = errors.New("threshold below policy minimum")
+	ErrWeightShareTooLarge = errors.New("single author holds too large a weight share")
+	ErrNoAuthorWeight      = errors.New("author set has no weight")
+)
+
+// AuthorPolicy holds organization-wide rules an author set must follow.
+// Zero fields are not enforced.
+type AuthorPolicy struct {
+	MinAuthors           int
+	MinThreshold         uint64
+	MaxSingleWeightShare float64 // largest share of the total weight one author may hold, in (0, 1]
+}
+
+// Check returns the first rule of p that authors and threshold violate.
+func (p AuthorPolicy) Check(authors []*Author, threshold uint64) error {
+	if len(authors) < p.MinAuthors {
+		return fmt.Errorf("%w: %d authors, policy requires %d", ErrTooFewAuthors, len(authors), p.MinAuthors)
+	}
+	if threshold < p.MinThreshold {
+		return fmt.Errorf("%w: threshold %d, policy requires %d", ErrThresholdTooLow, threshold, p.MinThreshold)
+	}
+	if p.MaxSingleWeightShare > 0 {
+		total, err := TotalWeight(authors)
+		if err != nil {
+			return err
+		}
+		if total == 0 {
+			return fmt.Errorf("%w: weight shares are undefined", ErrNoAuthorWeight)
+		}
+		for _, a := range authors {
+			if share := float64(a.Weight) / float64(total); share > p.MaxSingleWeightShare {
+				return fmt.Errorf("%w: %s holds %.2f of the weight, policy allows %.2f", ErrWeightShareTooLarge, a.Owner, share, p.MaxSingleWeightShare)
+			}
+		}
+	}
+	return nil
+}
+
+var ErrDisallowedAuthor = errors.New("author not on allowlist")
+
+// ValidateAgainstAllowlist returns an error naming the first author whose
+// owner allowed rejects, such as a key not held in an approved HSM.
+func ValidateAgainstAllowlist(authors []*Author, allowed func(Owner) bool) error {
+	for _, a := range authors {
+		if !allowed(a.Owner) {
+			return fmt.Errorf("%w: %s", ErrDisallowedAuthor, a.Owner)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAuthorization(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+	}
+	opts := ValidateOptions{Threshold: 1, UpdateAuthorThreshold: 2, MaxAuthors: 3, MaxAuthorActions: 2, MaxNameLength: 8}
+	add := func(name string, weight uint64) *AuthorAction {
+		return &AuthorAction{ActionType: AddAuthor, Author: NewAuthor(Name(name), weight)}
+	}
+	del := func(name string) *AuthorAction {
+		return &AuthorAction{ActionType: DeleteAuthor, Author: NewAuthor(Name(name), 0)}
+	}
+
+	tests := []struct {
+		name     string
+		action   *AccountAuthorAction
+		err      error
+		authors  int
+		warnings int
+	}{
+		{"unchanged", &AccountAuthorAction{}, nil, 2, 0},
+		{"add", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("carol", 1)}}, nil, 3, 0},
+		{"raise threshold", &AccountAuthorAction{Threshold: 3}, nil, 2, 1},
+		{"zero weight", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("carol", 0)}}, nil, 3, 1},
+		{"unreachable", &AccountAuthorAction{Threshold: 4}, ErrThresholdUnreachable, 0, 0},
+		{"update lock", &AccountAuthorAction{UpdateAuthorThreshold: 4}, ErrUpdateLock, 0, 0},
+		{"update lock after delete", &AccountAuthorAction{AuthorActions: []*AuthorAction{del("alice")}}, ErrUpdateLock, 0, 0},
+		{"delete keeping update reachable", &AccountAuthorAction{UpdateAuthorThreshold: 1, AuthorActions: []*AuthorAction{del("alice")}}, nil, 1, 0},
+		{"raise update threshold", &AccountAuthorAction{UpdateAuthorThreshold: 3}, nil, 2, 1},
+		{"too many authors", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("carol", 1), add("dave", 1)}}, ErrTooManyAuthors, 0, 0},
+		{"too many actions", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("carol", 1), del("carol"), add("carol", 1)}}, ErrTooManyAuthorActions, 0, 0},
+		{"name too long", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("verylongname", 1)}}, ErrOwnerTooLong, 0, 0},
+		{"add existing", &AccountAuthorAction{AuthorActions: []*AuthorAction{add("bob", 1)}}, ErrAuthorExists, 0, 0},
+		{"delete missing", &AccountAuthorAction{AuthorActions: []*AuthorAction{del("carol")}}, ErrAuthorNotFound, 0, 0},
+	}
+	for _, test := range tests {
+		result, err := ValidateAuthorization(current, test.action, opts)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(result.Authors) != test.authors || len(result.Warnings) != test.warnings {
+			t.Errorf("%s: got %d authors and warnings %q, want %d authors and %d warnings",
+				test.name, len(result.Authors), result.Warnings, test.authors, test.warnings)
+		}
+	}
+
+	if _, err := ValidateAuthorization(current, &AccountAuthorAction{}, ValidateOptions{}); !errors.Is(err, ErrZeroThreshold) {
+		t.Errorf("no thresholds: got %v, want %v", err, ErrZeroThreshold)
+	}
+	if _, err := ValidateAuthorization(current, nil, opts); !errors.Is(err, ErrNilAuthorAction) {
+		t.Errorf("nil action: got %v, want %v", err, ErrNilAuthorAction)
+	}
+	dup := append(current, NewAuthor(Name("bob"), 1))
+	if _, err := ValidateAuthorization(dup, &AccountAuthorAction{}, opts); !errors.Is(err, ErrDuplicateAuthor) {
+		t.Errorf("duplicate: got %v, want %v", err, ErrDuplicateAuthor)
+	}
+}
+
+func TestExplainValidation(t *testing.T) {
+	current := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+	}
+	action := &AccountAuthorAction{
+		Threshold:             4,
+		UpdateAuthorThreshold: 5,
+		AuthorActions: []*AuthorAction{
+			{ActionType: AddAuthor, Author: NewAuthor(Name("verylongname"), 0)},
+		},
+	}
+	opts := ValidateOptions{MaxAuthors: 2, MaxNameLength: 8}
+	explanation := ExplainValidation(current, action, opts)
+	for _, want := range []string{
+		"rejected with 4 problem(s)",
+		ErrTooManyAuthors.Error(),
+		ErrOwnerTooLong.Error(),
+		ErrThresholdUnreachable.Error(),
+		ErrUpdateLock.Error(),
+		"author verylongname has zero weight",
+		"total weight 3, threshold 4, update author threshold 5",
+	} {
+		if !strings.Contains(explanation, want) {
+			t.Errorf("explanation lacks %q:\n%s", want, explanation)
+		}
+	}
+
+	explanation = ExplainValidation(current, &AccountAuthorAction{}, ValidateOptions{Threshold: 2, UpdateAuthorThreshold: 2})
+	if !strings.HasPrefix(explanation, "author update is valid\n") || !strings.Contains(explanation, "losing author alice") {
+		t.Errorf("unexpected explanation:\n%s", explanation)
+	}
+}
+
+func TestAuthorPolicy(t *testing.T) {
+	policy := AuthorPolicy{MinAuthors: 3, MinThreshold: 2, MaxSingleWeightShare: 0.5}
+	authors := []*Author{
+		NewAuthor(Name("alice"), 2),
+		NewAuthor(Name("bob"), 1),
+		NewAuthor(Name("carol"), 1),
+	}
+	if err := policy.Check(authors, 2); err != nil {
+		t.Errorf("compliant set: %v", err)
+	}
+	if err := policy.Check(authors[:2], 2); !errors.Is(err, ErrTooFewAuthors) {
+		t.Errorf("two authors: got %v, want %v", err, ErrTooFewAuthors)
+	}
+	if err := policy.Check(authors, 1); !errors.Is(err, ErrThresholdTooLow) {
+		t.Errorf("threshold 1: got %v, want %v", err, ErrThresholdTooLow)
+	}
+	authors[0].Weight = 3
+	if err := policy.Check(authors, 2); !errors.Is(err, ErrWeightShareTooLarge) {
+		t.Errorf("alice at 60%%: got %v, want %v", err, ErrWeightShareTooLarge)
+	}
+	if err := (AuthorPolicy{}).Check(nil, 0); err != nil {
+		t.Errorf("empty policy: %v", err)
+	}
+	weightless := []*Author{NewAuthor(Name("alice"), 0), NewAuthor(Name("bob"), 0)}
+	if err := (AuthorPolicy{MaxSingleWeightShare: 0.5}).Check(weightless, 0); !errors.Is(err, ErrNoAuthorWeight) {
+		t.Errorf("weightless set: got %v, want %v", err, ErrNoAuthorWeight)
+	}
+}
+
+func TestValidateAgainstAllowlist(t *testing.T) {
+	approved := map[string]bool{
+		OwnerKey(Name("alice")): true,
+		OwnerKey(Name("bob")):   true,
+	}
+	allowed := func(owner Owner) bool { return approved[OwnerKey(owner)] }
+
+	authors := []*Author{NewAuthor(Name("alice"), 1), NewAuthor(Name("bob"), 2)}
+	if err := ValidateAgainstAllowlist(authors, allowed); err != nil {
+		t.Errorf("approved authors: %v", err)
+	}
+
+	authors = append(authors, NewAuthor(Name("mallory"), 1))
+	err := ValidateAgainstAllowlist(authors, allowed)
+	if !errors.Is(err, ErrDisallowedAuthor) {
+		t.Fatalf("got %v, want %v", err, ErrDisallowedAuthor)
+	}
+	if !strings.Contains(err.Error(), "mallory") {
+		t.Errorf("error %q does not name the owner", err)
+	}
+}
+
+// cancelAfterCtx reports itself cancelled once Err has been called n times.
+type cancelAfterCtx struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfterCtx) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestValidateBatch(t *testing.T) {
+	current := []*Author{NewAuthor(Name("alice"), 2), NewAuthor(Name("bob"), 1)}
+	opts := ValidateOptions{Threshold: 1, UpdateAuthorThreshold: 2}
+	actions := []*AccountAuthorAction{
+		{Threshold: 2},
+		{Threshold: 4},
+		{AuthorActions: []*AuthorAction{{ActionType: AddAuthor, Author: NewAuthor(Name("carol"), 1)}}},
+	}
+	sets := [][]*Author{current, current, current}
+
+	errs, err := ValidateBatch(context.Background(), actions, sets, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []error{nil, ErrThresholdUnreachable, nil}
+	for i := range want {
+		if !errors.Is(errs[i], want[i]) {
+			t.Errorf("action %d: got %v, want %v", i, errs[i], want[i])
+		}
+	}
+
+	errs, err = ValidateBatch(&cancelAfterCtx{Context: context.Background(), n: 2}, actions, sets, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if len(errs) != len(actions) || !errors.Is(errs[1], ErrThresholdUnreachable) || errs[2] != nil {
+		t.Errorf("cancelled batch: got %v", errs)
+	}
+
+	errs, err = ValidateBatch(context.Background(), []*AccountAuthorAction{nil, actions[0]}, sets[:2], opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(errs[0], ErrNilAuthorAction) || errs[1] != nil {
+		t.Errorf("batch with nil action: got %v", errs)
+	}
+
+	if _, err := ValidateBatch(context.Background(), actions, sets[:1], opts); err == nil {
+		t.Errorf("mismatched lengths: expected error")
+	}
+}
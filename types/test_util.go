@@ -0,0 +1,33 @@
+package types
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// GenerateTestAuthors returns n authors for use as test fixtures, cycling
+// through name, pubkey and address owners with weights between 1 and 10. The
+// owners and weights are drawn from a generator seeded with seed, so the same
+// arguments always produce the same authors.
+func GenerateTestAuthors(n int, seed int64) []*Author {
+	r := rand.New(rand.NewSource(seed))
+	authors := make([]*Author, n)
+	for i := range authors {
+		var owner Owner
+		switch AuthorType(i % 3) {
+		case AccountNameType:
+			owner = Name(fmt.Sprintf("test%d.%x", i, r.Uint32()))
+		case PubKeyType:
+			var pubKey PubKey
+			pubKey[0] = 4
+			r.Read(pubKey[1:])
+			owner = pubKey
+		case AddressType:
+			var address Address
+			r.Read(address[:])
+			owner = address
+		}
+		authors[i] = NewAuthor(owner, uint64(r.Intn(10)+1))
+	}
+	return authors
+}